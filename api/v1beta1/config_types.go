@@ -21,19 +21,88 @@ import (
 
 // ConfigSpec defines the desired state of Config
 type ConfigSpec struct {
+	// +kubebuilder:default={}
 	LoadBalancer HaLoadBalanceConfig `json:"loadbalancer,omitempty"`
-	DNS          DnsConfig           `json:"dns,omitempty"`
+	// +kubebuilder:default={}
+	DNS  DnsConfig  `json:"dns,omitempty"`
+	VIPs VipsConfig `json:"vips,omitempty"`
+	// +kubebuilder:default={}
+	Probes ProbesConfig `json:"probes,omitempty"`
+	// +kubebuilder:default={}
+	Alerts AlertsConfig `json:"alerts,omitempty"`
 }
 
 type HaLoadBalanceConfig struct {
+	// DefaultIngressHA runs keepalived holding the Ingress VIP, defaults to Enable
+	// +kubebuilder:default=Enable
 	DefaultIngressHA EnableDisable `json:"defaultingressha,omitempty"`
-	ApiLoadbalance   EnableDisable `json:"apiloadbalance,omitempty"`
+	// ApiLoadbalance runs HAProxy in front of the API servers and keepalived
+	// holding the API VIP, defaults to Enable
+	// +kubebuilder:default=Enable
+	ApiLoadbalance EnableDisable `json:"apiloadbalance,omitempty"`
+	// ApiVrrpCheck tracks the health of the HAProxy API load balancer in the
+	// keepalived API VRRP instances, defaults to Enable. It requires
+	// ApiLoadbalance.
+	// +kubebuilder:default=Enable
+	ApiVrrpCheck EnableDisable `json:"apivrrpcheck,omitempty"`
+	// Unicast sends the VRRP advertisements of the VIPs to the peer nodes
+	// instead of multicasting them, defaults to Disable. The peers are only
+	// known for the primary address family, additional VIPs require Disable.
+	// +kubebuilder:default=Disable
+	Unicast EnableDisable `json:"unicast,omitempty"`
 }
 
 type DnsConfig struct {
+	// NodesResolution resolves the node names with mDNS, defaults to Enable
+	// +kubebuilder:default=Enable
 	NodesResolution EnableDisable `json:"nodesresolution,omitempty"`
-	ApiResolution   EnableDisable `json:"apiresolution,omitempty"`
-	AppsResolution  EnableDisable `json:"appsresolution,omitempty"`
+	// ApiResolution resolves api and api-int to the API VIP, defaults to Enable
+	// +kubebuilder:default=Enable
+	ApiResolution EnableDisable `json:"apiresolution,omitempty"`
+	// AppsResolution resolves *.apps to the Ingress VIP, defaults to Enable
+	// +kubebuilder:default=Enable
+	AppsResolution EnableDisable `json:"appsresolution,omitempty"`
+}
+
+// VipsConfig lists the VIPs of the secondary address family on dual-stack
+// clusters. The VIPs of the primary address family are read from the
+// Infrastructure status.
+type VipsConfig struct {
+	AdditionalAPIVIPs     []string `json:"additionalapivips,omitempty"`
+	AdditionalIngressVIPs []string `json:"additionalingressvips,omitempty"`
+}
+
+// ProbesConfig configures the probes the operator periodically sends to the
+// VIPs to check they are reachable.
+type ProbesConfig struct {
+	// APIPort is the port of the API server readyz endpoint probed over HTTPS
+	// on the API VIPs, defaults to 6443
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=6443
+	APIPort int32 `json:"apiport,omitempty"`
+	// IngressPort is the port of the router health endpoint probed over HTTP
+	// on the Ingress VIPs, defaults to 1936
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=1936
+	IngressPort int32 `json:"ingressport,omitempty"`
+	// Timeout of a probe, defaults to 5s
+	// +kubebuilder:default="5s"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Period between the probes, defaults to 30s
+	// +kubebuilder:default="30s"
+	Period *metav1.Duration `json:"period,omitempty"`
+}
+
+// AlertsConfig configures the thresholds of the alerts shipped with the
+// operator, which it publishes as metrics.
+type AlertsConfig struct {
+	// VipFlappingThreshold is the number of changes of the nodes holding a
+	// VIP in an hour above which it is flapping, defaults to 5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	VipFlappingThreshold int32 `json:"vipflappingthreshold,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Enable;Disable
@@ -41,13 +110,116 @@ type EnableDisable string
 
 // ConfigStatus defines the observed state of Config
 type ConfigStatus struct {
+	// IngressVipOwner is the node holding the Ingress VIP, a comma separated
+	// list if several nodes claim it
 	IngressVipOwner string `json:"ingressvipowner,omitempty"`
-	APIVipOwner     string `json:"apivipowner,omitempty"`
+	// APIVipOwner is the node holding the API VIP, a comma separated list if
+	// several nodes claim it
+	APIVipOwner string `json:"apivipowner,omitempty"`
+	// IngressVipOwnerTransitionTime is the last time the Ingress VIP owner changed
+	IngressVipOwnerTransitionTime *metav1.Time `json:"ingressvipownertransitiontime,omitempty"`
+	// APIVipOwnerTransitionTime is the last time the API VIP owner changed
+	APIVipOwnerTransitionTime *metav1.Time `json:"apivipownertransitiontime,omitempty"`
+	// VRRPInstances are the states of the keepalived VRRP instances reported
+	// by the nodes
+	VRRPInstances []VRRPInstanceStatus `json:"vrrpInstances,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the overall state of the managed net services
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Components describe the state of each managed net service
+	Components []ComponentStatus `json:"components,omitempty"`
+	// DryRun summarizes the changes a reconcile would make, while the operator
+	// runs in dry-run mode
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+	// VipProbes are the results of the reachability probes of the VIPs
+	VipProbes []VipProbeStatus `json:"vipProbes,omitempty"`
+	// DNSRecordErrors lists the nodes whose CoreDNS doesn't resolve the api,
+	// api-int and *.apps names to the VIPs
+	DNSRecordErrors []NodeDNSRecordErrors `json:"dnsRecordErrors,omitempty"`
+	// DNSCheckError is the failure of the last check of the DNS records, if
+	// it failed. DNSRecordErrors are then those of the last successful check.
+	DNSCheckError string `json:"dnsCheckError,omitempty"`
 
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 }
 
+// ComponentStatus is the observed state of a managed net service
+type ComponentStatus struct {
+	// Name is one of keepalived, haproxy, mdns or coredns
+	Name string `json:"name"`
+	// Enabled tells whether the spec enables the component
+	Enabled bool `json:"enabled"`
+	// DesiredPods is the number of handler pods that should be running
+	DesiredPods int32 `json:"desiredpods"`
+	// ReadyPods is the number of handler pods that are ready
+	ReadyPods int32 `json:"readypods"`
+	// LastError is the error of the last sync of the component, if it failed
+	LastError string `json:"lasterror,omitempty"`
+}
+
+// DryRunStatus is the outcome of a dry-run reconcile
+type DryRunStatus struct {
+	// ObservedGeneration is the generation of the spec the changes were
+	// computed for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Time is when the changes were last computed
+	Time metav1.Time `json:"time"`
+	// Changes lists the handler objects that would be created, updated or
+	// deleted
+	Changes []string `json:"changes,omitempty"`
+	// Errors lists the failures the API server reported for the changes
+	Errors []string `json:"errors,omitempty"`
+}
+
+// VipProbeStatus is the result of the reachability probe of a VIP
+type VipProbeStatus struct {
+	// VIP is either api or ingress
+	VIP string `json:"vip"`
+	// Address is the probed VIP address
+	Address string `json:"address"`
+	// Reachable tells whether the VIP is reachable. It only changes once 3
+	// consecutive probes disagree with it.
+	Reachable bool `json:"reachable"`
+	// Message describes the failing probes while the VIP is unreachable, it
+	// doesn't change until the VIP is reachable again
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the VIP became reachable or
+	// unreachable. It is the anchor of the probes hysteresis: the probes
+	// disagreeing with Reachable since then are only counted, Reachable,
+	// Message and LastTransitionTime change together once there are 3 of
+	// them in a row.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// VRRPInstanceStatus lists the nodes by state of a keepalived VRRP instance.
+// The same nodes in the MASTER state for longer than a failover takes is a
+// split brain.
+type VRRPInstanceStatus struct {
+	// Name is the name of the VRRP instance
+	Name string `json:"name"`
+	// Masters lists the nodes reporting the MASTER state
+	Masters []string `json:"masters,omitempty"`
+	// Backups lists the nodes reporting the BACKUP state
+	Backups []string `json:"backups,omitempty"`
+	// Faults lists the nodes reporting the FAULT state
+	Faults []string `json:"faults,omitempty"`
+	// SeveralMastersSince is when the nodes listed in Masters were first seen
+	// together in the MASTER state, if there is more than one
+	SeveralMastersSince *metav1.Time `json:"severalMastersSince,omitempty"`
+}
+
+// NodeDNSRecordErrors lists the records the CoreDNS instance of a node doesn't
+// resolve as expected
+type NodeDNSRecordErrors struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+	// Errors describe the missing or wrong records
+	Errors []string `json:"errors"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=configs,scope=Namespaced
 // +kubebuilder:subresource:status
@@ -57,6 +229,7 @@ type Config struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	// +kubebuilder:default={}
 	Spec   ConfigSpec   `json:"spec,omitempty"`
 	Status ConfigStatus `json:"status,omitempty"`
 }
@@ -21,16 +21,47 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertsConfig) DeepCopyInto(out *AlertsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertsConfig.
+func (in *AlertsConfig) DeepCopy() *AlertsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
@@ -88,6 +119,9 @@ func (in *ConfigSpec) DeepCopyInto(out *ConfigSpec) {
 	*out = *in
 	out.LoadBalancer = in.LoadBalancer
 	out.DNS = in.DNS
+	in.VIPs.DeepCopyInto(&out.VIPs)
+	in.Probes.DeepCopyInto(&out.Probes)
+	out.Alerts = in.Alerts
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSpec.
@@ -103,6 +137,52 @@ func (in *ConfigSpec) DeepCopy() *ConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigStatus) DeepCopyInto(out *ConfigStatus) {
 	*out = *in
+	if in.IngressVipOwnerTransitionTime != nil {
+		in, out := &in.IngressVipOwnerTransitionTime, &out.IngressVipOwnerTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.APIVipOwnerTransitionTime != nil {
+		in, out := &in.APIVipOwnerTransitionTime, &out.APIVipOwnerTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.VRRPInstances != nil {
+		in, out := &in.VRRPInstances, &out.VRRPInstances
+		*out = make([]VRRPInstanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VipProbes != nil {
+		in, out := &in.VipProbes, &out.VipProbes
+		*out = make([]VipProbeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSRecordErrors != nil {
+		in, out := &in.DNSRecordErrors, &out.DNSRecordErrors
+		*out = make([]NodeDNSRecordErrors, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigStatus.
@@ -130,6 +210,32 @@ func (in *DnsConfig) DeepCopy() *DnsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HaLoadBalanceConfig) DeepCopyInto(out *HaLoadBalanceConfig) {
 	*out = *in
@@ -144,3 +250,123 @@ func (in *HaLoadBalanceConfig) DeepCopy() *HaLoadBalanceConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDNSRecordErrors) DeepCopyInto(out *NodeDNSRecordErrors) {
+	*out = *in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDNSRecordErrors.
+func (in *NodeDNSRecordErrors) DeepCopy() *NodeDNSRecordErrors {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDNSRecordErrors)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbesConfig) DeepCopyInto(out *ProbesConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesConfig.
+func (in *ProbesConfig) DeepCopy() *ProbesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRRPInstanceStatus) DeepCopyInto(out *VRRPInstanceStatus) {
+	*out = *in
+	if in.Masters != nil {
+		in, out := &in.Masters, &out.Masters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Faults != nil {
+		in, out := &in.Faults, &out.Faults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SeveralMastersSince != nil {
+		in, out := &in.SeveralMastersSince, &out.SeveralMastersSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRRPInstanceStatus.
+func (in *VRRPInstanceStatus) DeepCopy() *VRRPInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VRRPInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VipProbeStatus) DeepCopyInto(out *VipProbeStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipProbeStatus.
+func (in *VipProbeStatus) DeepCopy() *VipProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VipProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VipsConfig) DeepCopyInto(out *VipsConfig) {
+	*out = *in
+	if in.AdditionalAPIVIPs != nil {
+		in, out := &in.AdditionalAPIVIPs, &out.AdditionalAPIVIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIngressVIPs != nil {
+		in, out := &in.AdditionalIngressVIPs, &out.AdditionalIngressVIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipsConfig.
+func (in *VipsConfig) DeepCopy() *VipsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VipsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// renderedHashAnnotation is set on the applied handler objects to the hash of
+// their rendered content.
+const renderedHashAnnotation = "cluster-hosted-net-services.openshift.io/rendered-hash"
+
+// setRenderedHash sets the renderedHashAnnotation of a rendered object and
+// returns the hash.
+func setRenderedHash(obj *uns.Unstructured) (string, error) {
+	annotations := obj.GetAnnotations()
+	delete(annotations, renderedHashAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	// Maps are marshalled with sorted keys, the hash is stable
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed hashing %s", obj.GetName())
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[renderedHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+	return hash, nil
+}
+
+func appliedVersionKey(obj *uns.Unstructured) string {
+	return obj.GetKind() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// liveVersion changes whenever an object is changed by someone else than its
+// controller: the generation of the objects having one ignores their status
+// updates. It doesn't change with their labels and annotations though, which
+// are hashed along.
+func liveVersion(obj *uns.Unstructured) string {
+	if obj.GetGeneration() > 0 {
+		// Maps are marshalled with sorted keys, the hash is stable
+		metadata, _ := json.Marshal([]map[string]string{obj.GetLabels(), obj.GetAnnotations()})
+		return fmt.Sprintf("%d/%x", obj.GetGeneration(), sha256.Sum256(metadata))
+	}
+	return obj.GetResourceVersion()
+}
+
+// getLive returns the live version of a rendered object, nil if it doesn't
+// exist.
+func getLive(ctx context.Context, c client.Reader, obj *uns.Unstructured) (*uns.Unstructured, error) {
+	existing := &uns.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := c.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve existing %s", obj.GetName())
+	}
+	return existing, nil
+}
+
+// liveHashMatches tells whether the live object exists and carries the hash of
+// the rendered one.
+func liveHashMatches(existing *uns.Unstructured, hash string) bool {
+	return existing != nil && existing.GetAnnotations()[renderedHashAnnotation] == hash
+}
+
+// unchangedSinceApplied tells whether the live object carries the hash of the
+// rendered one, and wasn't changed since the operator applied it, in which case
+// applying it again would be a no-op. Edits of the live object, which keep the
+// hash annotation, are still reverted.
+func (r *ConfigReconciler) unchangedSinceApplied(existing, obj *uns.Unstructured, hash string) bool {
+	if !liveHashMatches(existing, hash) {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	applied, ok := r.appliedVersions[appliedVersionKey(obj)]
+	return ok && applied == liveVersion(existing)
+}
+
+// setAppliedVersion records the version of an object the operator applied.
+func (r *ConfigReconciler) setAppliedVersion(obj *uns.Unstructured) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.appliedVersions == nil {
+		r.appliedVersions = map[string]string{}
+	}
+	r.appliedVersions[appliedVersionKey(obj)] = liveVersion(obj)
+}
+
+func (r *ConfigReconciler) forgetAppliedVersion(obj *uns.Unstructured) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.appliedVersions, appliedVersionKey(obj))
+}
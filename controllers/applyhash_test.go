@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// liveObject is a client.Reader returning a copy of its object, if any
+type liveObject struct {
+	client.Reader
+	obj *uns.Unstructured
+}
+
+func (l liveObject) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if l.obj == nil {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	l.obj.DeepCopyInto(obj.(*uns.Unstructured))
+	return nil
+}
+
+func unchangedAfterGet(r *ConfigReconciler, c client.Reader, obj *uns.Unstructured, hash string) (bool, error) {
+	existing, err := getLive(context.Background(), c, obj)
+	if err != nil {
+		return false, err
+	}
+	return r.unchangedSinceApplied(existing, obj, hash), nil
+}
+
+func TestUnchangedSinceApplied(t *testing.T) {
+	t.Parallel()
+
+	rendered := func() *uns.Unstructured {
+		obj := &uns.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "mdns-template", "namespace": "cluster-hosted-net-services"},
+			"data":       map[string]interface{}{"config.hcl.tmpl": "bind_address = \"{{.NonVirtualIP}}\""},
+		}}
+		return obj
+	}
+
+	r := &ConfigReconciler{}
+	obj := rendered()
+	hash, err := setRenderedHash(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again, _ := setRenderedHash(obj); again != hash {
+		t.Fatalf("expected the hash to ignore its own annotation")
+	}
+
+	unchanged, err := unchangedAfterGet(r, liveObject{}, rendered(), hash)
+	if err != nil || unchanged {
+		t.Errorf("expected a missing object to be applied, got %v, %v", unchanged, err)
+	}
+
+	live := obj.DeepCopy()
+	live.SetResourceVersion("10")
+	unchanged, err = unchangedAfterGet(r, liveObject{obj: live}, rendered(), hash)
+	if err != nil || unchanged {
+		t.Errorf("expected an object never applied by the operator to be applied, got %v, %v", unchanged, err)
+	}
+
+	r.setAppliedVersion(live)
+	unchanged, err = unchangedAfterGet(r, liveObject{obj: live}, rendered(), hash)
+	if err != nil || !unchanged {
+		t.Errorf("expected an unchanged object to be skipped, got %v, %v", unchanged, err)
+	}
+
+	edited := live.DeepCopy()
+	edited.SetResourceVersion("11")
+	unchanged, err = unchangedAfterGet(r, liveObject{obj: edited}, rendered(), hash)
+	if err != nil || unchanged {
+		t.Errorf("expected an edited object to be applied again, got %v, %v", unchanged, err)
+	}
+
+	changed := rendered()
+	changed.Object["data"] = map[string]interface{}{"config.hcl.tmpl": "bind_address = \"0.0.0.0\""}
+	changedHash, _ := setRenderedHash(changed)
+	unchanged, err = unchangedAfterGet(r, liveObject{obj: live}, changed, changedHash)
+	if err != nil || unchanged {
+		t.Errorf("expected a changed rendered object to be applied, got %v, %v", unchanged, err)
+	}
+}
+
+func TestUnchangedSinceAppliedWithGeneration(t *testing.T) {
+	t.Parallel()
+
+	obj := &uns.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "DaemonSet",
+		"metadata": map[string]interface{}{
+			"name":      "master-cluster-hosted-keepalived",
+			"namespace": "cluster-hosted-net-services",
+			"labels":    map[string]interface{}{managedLabel: ClusterHostedNetServicesConfigCR},
+		},
+	}}
+	hash, err := setRenderedHash(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := &ConfigReconciler{}
+	live := obj.DeepCopy()
+	live.SetGeneration(2)
+	live.SetResourceVersion("10")
+	r.setAppliedVersion(live)
+
+	// The status updates don't bump the generation
+	statusUpdated := live.DeepCopy()
+	statusUpdated.SetResourceVersion("11")
+	unchanged, err := unchangedAfterGet(r, liveObject{obj: statusUpdated}, obj, hash)
+	if err != nil || !unchanged {
+		t.Errorf("expected a status update to be ignored, got %v, %v", unchanged, err)
+	}
+
+	// Neither do the label changes
+	unlabelled := live.DeepCopy()
+	unlabelled.SetLabels(nil)
+	unlabelled.SetResourceVersion("12")
+	unchanged, err = unchangedAfterGet(r, liveObject{obj: unlabelled}, obj, hash)
+	if err != nil || unchanged {
+		t.Errorf("expected a removed label to be restored, got %v, %v", unchanged, err)
+	}
+
+	annotated := live.DeepCopy()
+	annotations := annotated.GetAnnotations()
+	annotations["example.com/note"] = "edited"
+	annotated.SetAnnotations(annotations)
+	unchanged, err = unchangedAfterGet(r, liveObject{obj: annotated}, obj, hash)
+	if err != nil || unchanged {
+		t.Errorf("expected an added annotation to be reverted, got %v, %v", unchanged, err)
+	}
+}
@@ -46,12 +46,23 @@ const (
 	// ReasonInvalidConfiguration indicates that the configuration is invalid
 	ReasonInvalidConfiguration StatusReason = "InvalidConfiguration"
 
+	// ReasonMissingVips indicates that the API and Ingress VIPs could not be
+	// read from the Infrastructure status
+	ReasonMissingVips StatusReason = "MissingVIPs"
+
 	// ReasonDeployTimedOut indicates that the deployment timedOut
 	ReasonDeployTimedOut StatusReason = "DeployTimedOut"
 
 	// ReasonDeploymentCrashLooping indicates that the deployment is crashlooping
 	ReasonDeploymentCrashLooping StatusReason = "DeploymentCrashLooping"
 
+	// ReasonVipUnreachable indicates that the probe of a VIP failed
+	ReasonVipUnreachable StatusReason = "VIPUnreachable"
+
+	// ReasonSplitBrain indicates that several nodes are the master of a VRRP
+	// instance
+	ReasonSplitBrain StatusReason = "VRRPSplitBrain"
+
 	// ReasonUnsupported is an unsupported StatusReason
 	ReasonUnsupported StatusReason = "UnsupportedPlatform"
 )
@@ -70,12 +81,12 @@ func defaultStatusConditions() []osconfigv1.ClusterOperatorStatusCondition {
 
 // relatedObjects returns the current list of ObjectReference's for the
 // ClusterOperator objects's status.
-func relatedObjects() []osconfigv1.ObjectReference {
+func relatedObjects(namespace string) []osconfigv1.ObjectReference {
 	return []osconfigv1.ObjectReference{
 		{
 			Group:    "",
 			Resource: "namespaces",
-			Name:     componentNamespace,
+			Name:     namespace,
 		},
 		{
 			Group:    "cluster-hosted-net-services.openshift.io",
@@ -146,9 +157,9 @@ func (r *ConfigReconciler) ensureClusterOperator(clusterHostedConfig *clusterhos
 	}
 
 	needsUpadate := false
-	if !equality.Semantic.DeepEqual(co.Status.RelatedObjects, relatedObjects()) {
+	if !equality.Semantic.DeepEqual(co.Status.RelatedObjects, relatedObjects(r.Namespace)) {
 		needsUpadate = true
-		co.Status.RelatedObjects = relatedObjects()
+		co.Status.RelatedObjects = relatedObjects(r.Namespace)
 	}
 	if !equality.Semantic.DeepEqual(co.Status.Versions, operandVersions(r.ReleaseVersion)) {
 		needsUpadate = true
@@ -221,10 +232,14 @@ func (r *ConfigReconciler) updateCOStatus(newReason StatusReason, msg, progressM
 	case ReasonComplete:
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorAvailable, osconfigv1.ConditionTrue, string(newReason), msg))
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorProgressing, osconfigv1.ConditionFalse, string(newReason), progressMsg))
-	case ReasonInvalidConfiguration, ReasonDeployTimedOut:
+	case ReasonInvalidConfiguration, ReasonMissingVips, ReasonDeployTimedOut:
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorDegraded, osconfigv1.ConditionTrue, string(newReason), msg))
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorAvailable, osconfigv1.ConditionTrue, string(ReasonEmpty), ""))
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorProgressing, osconfigv1.ConditionTrue, string(newReason), progressMsg))
+	case ReasonVipUnreachable, ReasonSplitBrain:
+		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorDegraded, osconfigv1.ConditionTrue, string(newReason), msg))
+		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorAvailable, osconfigv1.ConditionTrue, string(ReasonEmpty), ""))
+		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorProgressing, osconfigv1.ConditionFalse, string(newReason), progressMsg))
 	case ReasonDeploymentCrashLooping:
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorDegraded, osconfigv1.ConditionTrue, string(newReason), msg))
 		v1helpers.SetStatusCondition(&conds, setStatusCondition(osconfigv1.OperatorAvailable, osconfigv1.ConditionFalse, string(newReason), msg))
@@ -234,7 +249,7 @@ func (r *ConfigReconciler) updateCOStatus(newReason StatusReason, msg, progressM
 	return r.syncStatus(co, conds)
 }
 
-func SetCOInDisabledState(osClient osclientset.Interface, version string) error {
+func SetCOInDisabledState(osClient osclientset.Interface, version, namespace string) error {
 	//The CVO should have created the CO
 	co, err := osClient.ConfigV1().ClusterOperators().Get(context.Background(), clusterOperatorName, metav1.GetOptions{})
 
@@ -250,7 +265,7 @@ func SetCOInDisabledState(osClient osclientset.Interface, version string) error
 		v1helpers.SetStatusCondition(&co.Status.Conditions, c)
 	}
 	co.Status.Versions = operandVersions(version)
-	co.Status.RelatedObjects = relatedObjects()
+	co.Status.RelatedObjects = relatedObjects(namespace)
 
 	_, err = osClient.ConfigV1().ClusterOperators().UpdateStatus(context.Background(), co, metav1.UpdateOptions{})
 	return err
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/openshift/cluster-network-operator/pkg/render"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// Component is a net service the operator deploys on the cluster nodes
+type Component interface {
+	// Name identifies the component in the Config status
+	Name() string
+	// Enabled tells whether the spec asks for the component to be deployed
+	Enabled(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) bool
+	// AddRenderData adds the component specific fields to the render data
+	// shared by the handler components
+	AddRenderData(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, sc *syncContext, data *render.RenderData)
+	// ManifestDirs lists the manifest directories of the component in the
+	// order they are applied, they are deleted in the reverse order
+	ManifestDirs(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) []manifestDir
+	// DaemonSets lists the handler DaemonSets deployed by the component
+	DaemonSets() []string
+	// Ready returns the number of pods of the component that should be
+	// running and of those that are ready
+	Ready(ctx context.Context, c client.Reader, namespace string) (int32, int32, error)
+	// Dependencies lists the components that have to be rolled out before
+	// this one is synced
+	Dependencies() []string
+}
+
+// manifestDir is a directory of manifests, under the cluster-hosted bindata
+// directory, rendered as a whole
+type manifestDir struct {
+	Name string
+	// Disabled manifests are deleted even though the component is enabled
+	Disabled bool
+}
+
+// components is the registry of the managed net services, in sync order.
+var components = []Component{
+	newKeepalivedComponent(),
+	newHaproxyComponent(),
+	newMDNSComponent(),
+	newCoreDNSComponent(),
+}
+
+// daemonSetComponent implements the parts of Component common to the
+// components deployed as DaemonSets.
+type daemonSetComponent struct {
+	name         string
+	daemonSets   []string
+	dependencies []string
+}
+
+func (c *daemonSetComponent) Name() string {
+	return c.name
+}
+
+func (c *daemonSetComponent) DaemonSets() []string {
+	return c.daemonSets
+}
+
+func (c *daemonSetComponent) Dependencies() []string {
+	return c.dependencies
+}
+
+func (c *daemonSetComponent) Ready(ctx context.Context, reader client.Reader, namespace string) (int32, int32, error) {
+	var desired, ready int32
+	for _, dsName := range c.daemonSets {
+		ds := &appsv1.DaemonSet{}
+		err := reader.Get(ctx, types.NamespacedName{Name: dsName, Namespace: namespace}, ds)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		desired += ds.Status.DesiredNumberScheduled
+		ready += ds.Status.NumberReady
+	}
+	return desired, ready, nil
+}
+
+// syncComponent applies the manifests of an enabled component, or deletes
+// those of a disabled one.
+func (r *ConfigReconciler) syncComponent(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext, c Component) error {
+	data := r.handlerRenderData(sc)
+	c.AddRenderData(&instance.Spec, sc, &data)
+	dirs := c.ManifestDirs(&instance.Spec)
+
+	if !c.Enabled(&instance.Spec) {
+		r.Log.Info("Delete resources", "component", c.Name())
+		for i := len(dirs) - 1; i >= 0; i-- {
+			if err := r.renderAndDelete(instance, sc, data, dirs[i].Name); err != nil {
+				return errors.Wrapf(err, "failed deleting %s", dirs[i].Name)
+			}
+		}
+		return nil
+	}
+
+	r.Log.Info("Create resources", "component", c.Name())
+	rendered, err := r.renderComponent(instance, data, dirs)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if dir.Disabled {
+			if err := r.renderAndDelete(instance, sc, data, dir.Name); err != nil {
+				return errors.Wrapf(err, "failed deleting %s", dir.Name)
+			}
+			continue
+		}
+		if err := r.applyObjects(sc, rendered[dir.Name], applyObject); err != nil {
+			return errors.Wrapf(err, "failed applying %s", dir.Name)
+		}
+	}
+	return nil
+}
+
+// renderComponent renders the manifest directories of a component that aren't
+// disabled. The whole component is rendered at once for its DaemonSets to
+// carry the hash of its ConfigMaps.
+func (r *ConfigReconciler) renderComponent(instance *clusterhostednetservicesopenshiftiov1beta1.Config, data render.RenderData, dirs []manifestDir) (map[string][]*uns.Unstructured, error) {
+	rendered := map[string][]*uns.Unstructured{}
+	all := []*uns.Unstructured{}
+	for _, dir := range dirs {
+		if dir.Disabled {
+			continue
+		}
+		objs, err := r.renderObjects(instance, data, dir.Name)
+		if err != nil {
+			return nil, err
+		}
+		rendered[dir.Name] = objs
+		all = append(all, objs...)
+	}
+	if err := stampConfigHashes(all); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
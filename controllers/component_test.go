@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"testing"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+func TestComponentsRegistry(t *testing.T) {
+	t.Parallel()
+
+	synced := map[string]bool{}
+	for _, c := range components {
+		if synced[c.Name()] {
+			t.Errorf("component %s registered twice", c.Name())
+		}
+		for _, dep := range c.Dependencies() {
+			if !synced[dep] {
+				t.Errorf("component %s depends on %s, which isn't synced before it", c.Name(), dep)
+			}
+		}
+		synced[c.Name()] = true
+	}
+}
+
+func TestPendingDependency(t *testing.T) {
+	t.Parallel()
+
+	coredns := newCoreDNSComponent()
+	enabled := &clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+		DNS: clusterhostednetservicesopenshiftiov1beta1.DnsConfig{ApiResolution: "Enable"},
+	}
+	testCases := []struct {
+		name     string
+		spec     *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec
+		pending  map[string]string
+		expected string
+	}{
+		{
+			name:     "nothing pending",
+			spec:     enabled,
+			pending:  map[string]string{},
+			expected: "",
+		},
+		{
+			name:     "unrelated component pending",
+			spec:     enabled,
+			pending:  map[string]string{"haproxy": "haproxy"},
+			expected: "",
+		},
+		{
+			name:     "dependency rolling out",
+			spec:     enabled,
+			pending:  map[string]string{"mdns": "mdns"},
+			expected: "mdns",
+		},
+		{
+			name:     "disabled component",
+			spec:     &clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{},
+			pending:  map[string]string{"keepalived": "keepalived"},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if dep := pendingDependency(coredns, tc.spec, tc.pending); dep != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, dep)
+			}
+		})
+	}
+}
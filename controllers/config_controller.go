@@ -19,29 +19,38 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/openshift/cluster-network-operator/pkg/apply"
 	"github.com/openshift/cluster-network-operator/pkg/render"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	osconfigv1 "github.com/openshift/api/config/v1"
 	osclientset "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/pkg/errors"
 
 	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/images"
-	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/names"
+	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/manifests"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
 )
@@ -49,26 +58,60 @@ import (
 const (
 	// ClusterHostedNetServicesConfigCR is the name of the Config singleton resource
 	ClusterHostedNetServicesConfigCR = "net-configuration"
+
+	// managedLabel marks the objects rendered from the Config singleton. The
+	// handler objects live outside of the Config namespace and can't be owned
+	// by it, changes to the labeled ones trigger a reconcile instead.
+	managedLabel = "cluster-hosted-net-services.openshift.io/config"
 )
 
-var containerImages *images.Images
-var componentNamespace string
-var onPremPlatformAPIServerInternalIP string
-var onPremPlatformIngressIP string
+// syncContext holds what a reconcile read from the cluster before syncing the
+// components. It's built anew by every reconcile.
+type syncContext struct {
+	apiVips     []string
+	ingressVips []string
+	images      *images.Images
+	instance    *clusterhostednetservicesopenshiftiov1beta1.Config
+	// client writes the handler objects, a dry-run client in dry-run mode
+	client client.Client
+	dryRun bool
+}
 
 // ConfigReconciler reconciles a Config object
 type ConfigReconciler struct {
 	client.Client
-	Log            logr.Logger
-	Scheme         *runtime.Scheme
-	OSClient       osclientset.Interface
-	ImagesFilename string
-	ReleaseVersion string
-}
-
-func init() {
-	componentNamespace = os.Getenv("COMPONENT_NAMESPACE")
-	// TODO : log error if componentNamespace is empty
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	OSClient osclientset.Interface
+	// Namespace is the namespace of the operator and of the Config singleton
+	Namespace string
+	// HandlerNamespace is the namespace of the handler DaemonSets
+	HandlerNamespace string
+	// ImagesConfigMap is the ConfigMap of the operator namespace holding the
+	// images
+	ImagesConfigMap string
+	ReleaseVersion  string
+	// DeployTimeout is how long the handler DaemonSets may take to roll out
+	// before the operator is Degraded, DefaultDeployTimeout if unset
+	DeployTimeout time.Duration
+	// Recorder records Events on the Config
+	Recorder record.EventRecorder
+	// DryRun makes every reconcile a dry-run, as the DryRunAnnotation does
+	// for a single Config
+	DryRun bool
+	// ManifestDir overrides the handler manifests embedded in the binary with
+	// those of its cluster-hosted directory, for development
+	ManifestDir string
+
+	// mu guards the state kept between reconciles
+	mu            sync.Mutex
+	rolloutStarts map[string]rolloutStart
+	lastImages    *images.Images
+	// lastAPIVips and lastIngressVips are the VIPs of the last reconcile
+	lastAPIVips     []string
+	lastIngressVips []string
+	// appliedVersions is the version of the handler objects once applied
+	appliedVersions map[string]string
 }
 
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
@@ -104,34 +147,51 @@ func IsEnabled(osClient osclientset.Interface) (bool, error) {
 	return true, nil
 }
 
+// UpdateDefaultConfigCR names the singleton Config. Its spec is left empty, the
+// CRD schema defaults fill every unset field.
 func UpdateDefaultConfigCR(instance *clusterhostednetservicesopenshiftiov1beta1.Config, opertorNamespace string) {
 	instance.SetName(ClusterHostedNetServicesConfigCR)
 	instance.SetNamespace(opertorNamespace)
-	instance.Spec = clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
-		LoadBalancer: clusterhostednetservicesopenshiftiov1beta1.HaLoadBalanceConfig{
-			DefaultIngressHA: "Enable",
-			ApiLoadbalance:   "Enable",
-		},
-		DNS: clusterhostednetservicesopenshiftiov1beta1.DnsConfig{
-			NodesResolution: "Enable",
-			ApiResolution:   "Enable",
-			AppsResolution:  "Enable",
-		},
+	instance.Spec = clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{}
+}
+
+// loadContainerImages reads the operand images, on every reconcile so that
+// updates of the images ConfigMap are rolled out.
+func (r *ConfigReconciler) loadContainerImages() (*images.Images, error) {
+	loadedImages := &images.Images{}
+	cm := &corev1.ConfigMap{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: r.ImagesConfigMap, Namespace: r.Namespace}, cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to read ConfigMap %s", r.ImagesConfigMap)
 	}
+	if err := images.ParseContainerImages(loadedImages, []byte(cm.Data[images.ImagesJSONKey])); err != nil {
+		return nil, errors.Wrapf(err, "invalid ConfigMap %s", r.ImagesConfigMap)
+	}
+	return loadedImages, nil
 }
 
-func (r *ConfigReconciler) updateVipsDetails() error {
+func (r *ConfigReconciler) getPlatformVips() (string, string, error) {
 	ctx := context.Background()
 
 	infra, err := r.OSClient.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
 	if err != nil {
 		r.Log.Error(err, "Failed to retrieve VIP details")
-		return err
+		return "", "", err
 	}
 
-	onPremPlatformAPIServerInternalIP = infra.Status.PlatformStatus.BareMetal.APIServerInternalIP
-	onPremPlatformIngressIP = infra.Status.PlatformStatus.BareMetal.IngressIP
-	return nil
+	return platformVips(infra)
+}
+
+// vipsDetails returns the API and Ingress VIPs of every address family.
+func vipsDetails(instance *clusterhostednetservicesopenshiftiov1beta1.Config, apiVip, ingressVip string) ([]string, []string, error) {
+	apiVips, err := dualStackVips(apiVip, instance.Spec.VIPs.AdditionalAPIVIPs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid API VIPs")
+	}
+	ingressVips, err := dualStackVips(ingressVip, instance.Spec.VIPs.AdditionalIngressVIPs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid Ingress VIPs")
+	}
+	return apiVips, ingressVips, nil
 }
 
 func (r *ConfigReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -155,22 +215,16 @@ func (r *ConfigReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	if req.NamespacedName.Name != ClusterHostedNetServicesConfigCR ||
-		req.NamespacedName.Namespace != componentNamespace {
+		req.NamespacedName.Namespace != r.Namespace {
 		r.Log.V(1).Info("ignoring invalid CR", "name", req.NamespacedName.Name)
 		return reconcile.Result{}, nil
 	}
 
-	if onPremPlatformAPIServerInternalIP == "" || onPremPlatformIngressIP == "" {
-		if err = r.updateVipsDetails(); err != nil {
-			return reconcile.Result{}, err
-		}
-		r.Log.Info("VIPS: %s , %s", onPremPlatformAPIServerInternalIP, onPremPlatformIngressIP)
-	}
 	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
-	if err := r.Client.Get(ctxt, types.NamespacedName{Name: ClusterHostedNetServicesConfigCR, Namespace: componentNamespace}, instance); err != nil {
+	if err := r.Client.Get(ctxt, types.NamespacedName{Name: ClusterHostedNetServicesConfigCR, Namespace: r.Namespace}, instance); err != nil {
 		if apierrors.IsNotFound(err) {
 			// Default Config object not found, create it.
-			UpdateDefaultConfigCR(instance, componentNamespace)
+			UpdateDefaultConfigCR(instance, r.Namespace)
 			err = r.Create(context.TODO(), instance)
 			if err != nil {
 				r.Log.Error(err, "Failed to create default Operator Config", "Name", ClusterHostedNetServicesConfigCR)
@@ -183,21 +237,63 @@ func (r *ConfigReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 	r.Log.Info("Returned object name", "name", req.NamespacedName.Name)
 
-	if containerImages == nil {
-		containerImages = new(images.Images)
-
-		if err := images.GetContainerImages(containerImages, r.ImagesFilename); err != nil {
-			// FIXME: set containerImages to nil so in case of error we'll try to retrieve the images in next request
-			containerImages = nil
-			// Images config map is not valid
-			// Requeue request.
-			r.Log.Error(err, "invalid contents in images Config Map")
-			co_err := r.updateCOStatus(ReasonInvalidConfiguration, err.Error(), "invalid contents in images Config Map")
-			if co_err != nil {
-				return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, co_err)
-			}
-			return ctrl.Result{}, err
+	apiVip, ingressVip, err := r.getPlatformVips()
+	if err != nil {
+		r.Log.Error(err, "unable to determine VIPs")
+		co_err := r.updateCOStatus(ReasonMissingVips, err.Error(), "unable to determine VIPs")
+		if co_err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, co_err)
+		}
+		return reconcile.Result{}, err
+	}
+	sc := &syncContext{instance: instance, client: r.Client}
+	sc.apiVips, sc.ingressVips, err = vipsDetails(instance, apiVip, ingressVip)
+	if err != nil {
+		r.Log.Error(err, "invalid VIPs configuration")
+		co_err := r.updateCOStatus(ReasonInvalidConfiguration, err.Error(), "invalid VIPs configuration")
+		if co_err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, co_err)
 		}
+		return reconcile.Result{}, err
+	}
+	r.Log.Info("VIPs", "api", sc.apiVips, "ingress", sc.ingressVips)
+	r.mu.Lock()
+	if r.lastAPIVips != nil && (!reflect.DeepEqual(r.lastAPIVips, sc.apiVips) || !reflect.DeepEqual(r.lastIngressVips, sc.ingressVips)) {
+		r.eventf(instance, corev1.EventTypeNormal, EventReasonVIPsChanged, "VIPs changed from API %v, Ingress %v to API %v, Ingress %v",
+			r.lastAPIVips, r.lastIngressVips, sc.apiVips, sc.ingressVips)
+	}
+	r.lastAPIVips, r.lastIngressVips = sc.apiVips, sc.ingressVips
+	r.mu.Unlock()
+
+	sc.images, err = r.loadContainerImages()
+	if err != nil {
+		// Images config map is not valid
+		// Requeue request.
+		imageLoadFailuresTotal.Inc()
+		r.Log.Error(err, "invalid contents in images Config Map")
+		co_err := r.updateCOStatus(ReasonInvalidConfiguration, err.Error(), "invalid contents in images Config Map")
+		if co_err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, co_err)
+		}
+		return ctrl.Result{}, err
+	}
+	r.mu.Lock()
+	if r.lastImages != nil && *r.lastImages != *sc.images {
+		// Only the components using the changed images get new DaemonSet
+		// templates, so only they are rolled out
+		r.Log.Info("container images changed", "from", *r.lastImages, "to", *sc.images)
+		r.eventf(instance, corev1.EventTypeNormal, EventReasonImagesChanged, "Container images changed: %s",
+			strings.Join(changedImages(r.lastImages, sc.images), ", "))
+	}
+	r.lastImages = sc.images
+	r.mu.Unlock()
+
+	if r.isDryRun(instance) {
+		// Nothing is changed, the ClusterOperator status included
+		if err := r.dryRunSync(instance, sc); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed updating Config dry-run status")
+		}
+		return ctrl.Result{}, nil
 	}
 
 	// TODO customize this code to check of handler resources already created
@@ -206,220 +302,361 @@ func (r *ConfigReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Syncing state: %v", clusterOperatorName, err)
 	}
 
-	err = r.syncNamespace(instance)
+	previousComponents := instance.Status.Components
+
+	err = r.syncNamespace(instance, sc)
 	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed applying Namespace")
+		err = errors.Wrap(err, "failed applying Namespace")
+		r.eventf(instance, corev1.EventTypeWarning, EventReasonSyncFailed, "%v", err)
+		return ctrl.Result{}, err
 	}
 
-	err = r.syncRBAC(instance)
+	err = r.syncRBAC(instance, sc)
 	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed applying RBAC")
+		err = errors.Wrap(err, "failed applying RBAC")
+		r.eventf(instance, corev1.EventTypeWarning, EventReasonSyncFailed, "%v", err)
+		return ctrl.Result{}, err
 	}
 
-	/*
-		err = r.syncKeepalived(instance)
+	// A failing component shouldn't prevent the others from being synced.
+	// An enabled component is only synced once its dependencies are rolled
+	// out though, so that a VIP change first moves the VIPs and only then the
+	// DNS records pointing to them, keeping the API reachable. Rollouts
+	// exceeding the deploy timeout no longer hold their dependents back.
+	syncErrors := map[string]error{}
+	pending := map[string]string{}
+	for _, c := range components {
+		if dep := pendingDependency(c, &instance.Spec, pending); dep != "" {
+			r.Log.Info("waiting for dependency before syncing", "component", c.Name(), "dependency", dep)
+			pending[c.Name()] = dep
+			componentSyncsTotal.WithLabelValues(c.Name(), syncResultWaiting).Inc()
+			continue
+		}
+		start := time.Now()
+		err := r.syncComponent(instance, sc, c)
+		componentSyncDuration.WithLabelValues(c.Name()).Observe(time.Since(start).Seconds())
 		if err != nil {
-			return ctrl.Result{}, errors.Wrap(err, "failed applying Keepalived")
+			componentSyncsTotal.WithLabelValues(c.Name(), syncResultError).Inc()
+			syncErrors[c.Name()] = errors.Wrapf(err, "failed applying %s", c.Name())
+			r.eventf(instance, corev1.EventTypeWarning, EventReasonSyncFailed, "%v", syncErrors[c.Name()])
+			pending[c.Name()] = c.Name()
+			continue
+		}
+		componentSyncsTotal.WithLabelValues(c.Name(), syncResultSuccess).Inc()
+		rolling, err := r.componentRollingOut(c, time.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if rolling {
+			pending[c.Name()] = c.Name()
 		}
-	*/
-	err = r.syncHaproxy(instance)
-	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed applying Haproxy")
 	}
 
-	err = r.syncMDNS(instance)
+	err = r.updateConfigStatus(instance, syncErrors)
 	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed applying MDNS")
+		return ctrl.Result{}, errors.Wrap(err, "failed updating Config status")
 	}
+	r.recordComponentTransitions(instance, previousComponents)
 
-	err = r.syncCoreDNS(instance)
-	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed applying CoreDNS")
+	for _, c := range components {
+		if syncErrors[c.Name()] != nil {
+			return ctrl.Result{}, syncErrors[c.Name()]
+		}
 	}
 
-	err = r.updateCOStatus(ReasonComplete, "Applying Cluster hosted net services resources completed", "")
+	rollout, err := r.checkRollouts(time.Now())
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Available state: %v", clusterOperatorName, err)
+		return ctrl.Result{}, errors.Wrap(err, "failed checking handler rollouts")
+	}
+
+	// Keep following the rollouts and the VIPs, DaemonSet status changes,
+	// VRRP states and probe results updates also trigger a reconcile. The
+	// VRRP states don't change once several masters become a split brain
+	// though, check them again then.
+	requeue := rolloutResync
+	if pending := pendingSplitBrain(instance.Status.VRRPInstances, time.Now()); pending > 0 && pending < requeue {
+		requeue = pending
 	}
+	vipsReason, vipsMsg := vipsDegraded(&instance.Status, time.Now())
 
-	return ctrl.Result{}, nil
+	switch {
+	case len(rollout.CrashLooping) > 0:
+		msg := fmt.Sprintf("Handler pods crashlooping: %s", strings.Join(rollout.CrashLooping, ", "))
+		err = r.updateCOStatus(ReasonDeploymentCrashLooping, msg, "")
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, err)
+		}
+	case len(rollout.TimedOut) > 0:
+		msg := fmt.Sprintf("Rollout of %s didn't complete within %s", strings.Join(rollout.TimedOut, ", "), r.deployTimeout())
+		err = r.updateCOStatus(ReasonDeployTimedOut, msg, "Waiting for the handler DaemonSets rollout")
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, err)
+		}
+	case len(rollout.RollingOut) > 0:
+		msg := fmt.Sprintf("Rolling out %s", strings.Join(rollout.RollingOut, ", "))
+		err = r.updateCOStatus(ReasonSyncing, "", msg)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Syncing state: %v", clusterOperatorName, err)
+		}
+	case vipsReason != "":
+		err = r.updateCOStatus(vipsReason, vipsMsg, "")
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Degraded state: %v", clusterOperatorName, err)
+		}
+	default:
+		err = r.updateCOStatus(ReasonComplete, "Applying Cluster hosted net services resources completed", "")
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to put %q ClusterOperator in Available state: %v", clusterOperatorName, err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
-func (r *ConfigReconciler) syncRBAC(instance *clusterhostednetservicesopenshiftiov1beta1.Config) error {
+func (r *ConfigReconciler) syncRBAC(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext) error {
 
 	// TODO:  add here code to check if RBAC resources already exist
 	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = os.Getenv("HANDLER_NAMESPACE")
+	data.Data["HandlerNamespace"] = r.HandlerNamespace
 
-	err := r.renderAndApply(instance, data, "rbac")
+	err := r.renderAndApply(instance, sc, data, "rbac")
 	if err != nil {
 		errors.Wrap(err, "failed applying RBAC")
 		return err
 	}
-	return r.renderAndApply(instance, data, "rbac")
+	return r.renderAndApply(instance, sc, data, "rbac")
 }
 
-func (r *ConfigReconciler) syncKeepalived(instance *clusterhostednetservicesopenshiftiov1beta1.Config) error {
-
-	// TODO:  add here code to check if Keepalived resources already exist
+// handlerRenderData returns the render data shared by the handler components.
+func (r *ConfigReconciler) handlerRenderData(sc *syncContext) render.RenderData {
 	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = os.Getenv("HANDLER_NAMESPACE")
-	data.Data["OnPremPlatformAPIServerInternalIP"] = onPremPlatformAPIServerInternalIP
-	data.Data["OnPremPlatformIngressIP"] = onPremPlatformIngressIP
-	data.Data["BaremetalRuntimeCfgImage"] = containerImages.BaremetalRuntimecfg
-	data.Data["KeepalivedImage"] = containerImages.KeepalivedIpfailover
-
-	err := r.renderAndApply(instance, data, "keepalived-configmap")
-	if err != nil {
-		errors.Wrap(err, "failed applying keepalived-configmap ")
-		return err
-	}
-	return r.renderAndApply(instance, data, "keepalived-daemonset")
+	data.Data["HandlerNamespace"] = r.HandlerNamespace
+	addVipsRenderData(&data, sc.apiVips, sc.ingressVips)
+	data.Data["BaremetalRuntimeCfgImage"] = sc.images.BaremetalRuntimecfg
+	return data
 }
 
-func (r *ConfigReconciler) syncCoreDNS(instance *clusterhostednetservicesopenshiftiov1beta1.Config) error {
-
-	// TODO:  add here code to check if CoreDNS resources already exist
-	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = os.Getenv("HANDLER_NAMESPACE")
-	data.Data["OnPremPlatformAPIServerInternalIP"] = onPremPlatformAPIServerInternalIP
-	data.Data["OnPremPlatformIngressIP"] = onPremPlatformIngressIP
-	data.Data["BaremetalRuntimeCfgImage"] = containerImages.BaremetalRuntimecfg
-	data.Data["CorednsImage"] = containerImages.Coredns
-
-	err := r.renderAndApply(instance, data, "coredns-configmap")
-	if err != nil {
-		errors.Wrap(err, "failed applying CoreDNS-configmap ")
-		return err
-	}
-	return r.renderAndApply(instance, data, "coredns-daemonset")
+func (r *ConfigReconciler) isManagedHandlerObject(meta metav1.Object) bool {
+	return meta.GetNamespace() == r.HandlerNamespace && meta.GetLabels()[managedLabel] != ""
 }
 
-func (r *ConfigReconciler) syncMDNS(instance *clusterhostednetservicesopenshiftiov1beta1.Config) error {
-	var err error
-
-	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = os.Getenv("HANDLER_NAMESPACE")
-	data.Data["OnPremPlatformAPIServerInternalIP"] = onPremPlatformAPIServerInternalIP
-	data.Data["OnPremPlatformIngressIP"] = onPremPlatformIngressIP
-	data.Data["BaremetalRuntimeCfgImage"] = containerImages.BaremetalRuntimecfg
-	data.Data["MdnsPublisherImage"] = containerImages.MdnsPublisher
-
-	if instance.Spec.DNS.NodesResolution == "Enable" {
-		r.Log.Info("Create mDNS resources")
-		err = r.renderAndApply(instance, data, "mdns-configmap")
-		if err != nil {
-			errors.Wrap(err, "failed applying mdns-configmap ")
-			return err
-		}
-		err = r.renderAndApply(instance, data, "mdns-daemonset")
-	} else {
-		r.Log.Info("Delete mDNS resources")
-		err = r.renderAndDelete(instance, data, "mdns-daemonset")
-		if err != nil {
-			errors.Wrap(err, "failed Deleting mdns-configmap ")
-			return err
-		}
-		err = r.renderAndDelete(instance, data, "mdns-configmap")
+func (r *ConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Any change to a rendered handler object, including its deletion, is
+	// reverted by reconciling the Config singleton
+	toConfig := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(handler.MapObject) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{
+				Name:      ClusterHostedNetServicesConfigCR,
+				Namespace: r.Namespace,
+			}}}
+		}),
+	}
+	managed := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return r.isManagedHandlerObject(e.Meta)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return r.isManagedHandlerObject(e.Meta)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return r.isManagedHandlerObject(e.MetaOld) || r.isManagedHandlerObject(e.MetaNew)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return r.isManagedHandlerObject(e.Meta)
+		},
 	}
-	return err
-
-}
-
-func (r *ConfigReconciler) syncHaproxy(instance *clusterhostednetservicesopenshiftiov1beta1.Config) error {
-	var err error
 
-	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = os.Getenv("HANDLER_NAMESPACE")
-	data.Data["OnPremPlatformAPIServerInternalIP"] = onPremPlatformAPIServerInternalIP
-	data.Data["BaremetalRuntimeCfgImage"] = containerImages.BaremetalRuntimecfg
-	data.Data["HaproxyImage"] = containerImages.HaproxyRouter
-
-	if instance.Spec.LoadBalancer.ApiLoadbalance == "Enable" {
-		r.Log.Info("Create HAProxy resources")
-		err = r.renderAndApply(instance, data, "haproxy-configmap")
-		if err != nil {
-			errors.Wrap(err, "failed applying haproxy-configmap ")
-			return err
-		}
-		err = r.renderAndApply(instance, data, "haproxy-daemonset")
-	} else {
-		r.Log.Info("Delete HAProxy resources")
-		err = r.renderAndDelete(instance, data, "haproxy-daemonset")
-		if err != nil {
-			errors.Wrap(err, "failed Deleting haproxy-configmap ")
-			return err
-		}
-		err = r.renderAndDelete(instance, data, "haproxy-configmap")
+	// Only VIP changes in the Infrastructure are relevant
+	vipsChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldInfra, ok := e.ObjectOld.(*osconfigv1.Infrastructure)
+			if !ok {
+				return false
+			}
+			newInfra, ok := e.ObjectNew.(*osconfigv1.Infrastructure)
+			if !ok {
+				return false
+			}
+			oldAPIVip, oldIngressVip, _ := platformVips(oldInfra)
+			newAPIVip, newIngressVip, _ := platformVips(newInfra)
+			return oldAPIVip != newAPIVip || oldIngressVip != newIngressVip
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
 	}
 
-	return err
-}
+	imagesConfigMap := predicate.NewPredicateFuncs(func(meta metav1.Object, _ runtime.Object) bool {
+		return meta.GetNamespace() == r.Namespace && meta.GetName() == r.ImagesConfigMap
+	})
 
-func (r *ConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&clusterhostednetservicesopenshiftiov1beta1.Config{}).
 		Owns(&corev1.Namespace{}).
+		Watches(&source.Kind{Type: &osconfigv1.Infrastructure{}}, toConfig, builder.WithPredicates(vipsChanged)).
+		Watches(&source.Kind{Type: &appsv1.DaemonSet{}}, toConfig, builder.WithPredicates(managed)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, toConfig, builder.WithPredicates(predicate.Or(managed, imagesConfigMap))).
 		Complete(r)
 }
-func (r *ConfigReconciler) syncNamespace(instance *clusterhostednetservicesopenshiftiov1beta1.Config) error {
+func (r *ConfigReconciler) syncNamespace(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext) error {
 
 	// TODO:  add here code to check if namespace exists
 	data := render.MakeRenderData()
-	data.Data["HandlerNamespace"] = os.Getenv("HANDLER_NAMESPACE")
-	return r.renderAndApply(instance, data, "namespace")
+	data.Data["HandlerNamespace"] = r.HandlerNamespace
+	return r.renderAndApply(instance, sc, data, "namespace")
 }
 
-func (r *ConfigReconciler) renderAndApply(instance *clusterhostednetservicesopenshiftiov1beta1.Config, data render.RenderData, sourceDirectory string) error {
-	return r.renderAndApplyOrDelete(instance, data, sourceDirectory, applyObject)
+func (r *ConfigReconciler) renderAndApply(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext, data render.RenderData, sourceDirectory string) error {
+	return r.renderAndApplyOrDelete(instance, sc, data, sourceDirectory, applyObject)
 }
 
-func (r *ConfigReconciler) renderAndDelete(instance *clusterhostednetservicesopenshiftiov1beta1.Config, data render.RenderData, sourceDirectory string) error {
-	return r.renderAndApplyOrDelete(instance, data, sourceDirectory, deleteObject)
+func (r *ConfigReconciler) renderAndDelete(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext, data render.RenderData, sourceDirectory string) error {
+	return r.renderAndApplyOrDelete(instance, sc, data, sourceDirectory, deleteObject)
 }
 
-type objFunc func(*ConfigReconciler, context.Context, client.Client, *uns.Unstructured) error
+type objFunc func(*ConfigReconciler, context.Context, *syncContext, *uns.Unstructured) error
 
-func (r *ConfigReconciler) renderAndApplyOrDelete(instance *clusterhostednetservicesopenshiftiov1beta1.Config, data render.RenderData, sourceDirectory string, fnObj objFunc) error {
-	var err error
-	objs := []*uns.Unstructured{}
+func (r *ConfigReconciler) renderAndApplyOrDelete(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext, data render.RenderData, sourceDirectory string, fnObj objFunc) error {
+	objs, err := r.renderObjects(instance, data, sourceDirectory)
+	if err != nil {
+		return err
+	}
+	return r.applyObjects(sc, objs, fnObj)
+}
 
-	sourceFullDirectory := filepath.Join(names.HandlerManifestDir, "cluster-hosted", sourceDirectory)
+// applyObjects runs fnObj on every rendered object.
+func (r *ConfigReconciler) applyObjects(sc *syncContext, objs []*uns.Unstructured, fnObj objFunc) error {
+	for _, obj := range objs {
+		// Now Run the fn on object
+		err := fnObj(r, context.TODO(), sc, obj)
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply object %v", obj)
+		}
+	}
 
-	objs, err = render.RenderDir(sourceFullDirectory, &data)
+	return nil
+}
+
+// renderObjects renders the manifests of a handler directory, labelled as
+// managed by the Config.
+func (r *ConfigReconciler) renderObjects(instance *clusterhostednetservicesopenshiftiov1beta1.Config, data render.RenderData, sourceDirectory string) ([]*uns.Unstructured, error) {
+	rendered, err := manifests.RenderHandlerDir(r.ManifestDir, sourceDirectory, &data)
 	if err != nil {
-		return errors.Wrapf(err, "failed to render cluster-hosted %s", sourceDirectory)
+		return nil, errors.Wrapf(err, "failed to render cluster-hosted %s", sourceDirectory)
 	}
 
 	// If no file found in directory - return error
-	if len(objs) == 0 {
-		return fmt.Errorf("No manifests rendered from %s", sourceFullDirectory)
+	if len(rendered) == 0 {
+		return nil, fmt.Errorf("No manifests rendered from %s", sourceDirectory)
 	}
 
-	for _, obj := range objs {
+	objs := []*uns.Unstructured{}
+	for _, obj := range rendered {
 		// RenderDir seems to add an extra null entry to the list. It appears to be because of the
 		// nested templates. This just makes sure we don't try to apply an empty obj.
 		if obj.GetName() == "" {
 			continue
 		}
 
-		// Now Run the fn on object
-		err = fnObj(r, context.TODO(), r.Client, obj)
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[managedLabel] = instance.Name
+		obj.SetLabels(labels)
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+// RenderManifests renders every handler object a reconcile of the Config
+// would apply, for the VIPs of the Infrastructure and the given images,
+// without reaching the cluster.
+func (r *ConfigReconciler) RenderManifests(instance *clusterhostednetservicesopenshiftiov1beta1.Config, infra *osconfigv1.Infrastructure, containerImages *images.Images) ([]*uns.Unstructured, error) {
+	apiVip, ingressVip, err := platformVips(infra)
+	if err != nil {
+		return nil, err
+	}
+	apiVips, ingressVips, err := vipsDetails(instance, apiVip, ingressVip)
+	if err != nil {
+		return nil, err
+	}
+	sc := &syncContext{apiVips: apiVips, ingressVips: ingressVips, images: containerImages}
+
+	objs := []*uns.Unstructured{}
+	data := render.MakeRenderData()
+	data.Data["HandlerNamespace"] = r.HandlerNamespace
+	for _, dir := range []string{"namespace", "rbac"} {
+		rendered, err := r.renderObjects(instance, data, dir)
 		if err != nil {
-			return errors.Wrapf(err, "failed to apply object %v", obj)
+			return nil, err
 		}
+		objs = append(objs, rendered...)
 	}
 
-	return nil
+	for _, c := range components {
+		if !c.Enabled(&instance.Spec) {
+			continue
+		}
+		data := r.handlerRenderData(sc)
+		c.AddRenderData(&instance.Spec, sc, &data)
+		dirs := c.ManifestDirs(&instance.Spec)
+		rendered, err := r.renderComponent(instance, data, dirs)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			objs = append(objs, rendered[dir.Name]...)
+		}
+	}
+	return objs, nil
 }
 
-func applyObject(r *ConfigReconciler, ctx context.Context, client client.Client, obj *uns.Unstructured) error {
+func applyObject(r *ConfigReconciler, ctx context.Context, sc *syncContext, obj *uns.Unstructured) error {
+	hash, err := setRenderedHash(obj)
+	if err != nil {
+		return err
+	}
+	existing, err := getLive(ctx, sc.client, obj)
+	if err != nil {
+		return err
+	}
+	if sc.dryRun && liveHashMatches(existing, hash) {
+		// The applied versions aren't known when the operator starts in
+		// dry-run mode, and a dry-run apply of an unchanged object still
+		// reports an update, only the objects whose hash differs are changed
+		r.Log.V(1).Info("Obj unchanged, skipping dry-run apply", "Name", obj.GetName())
+		return nil
+	}
+	if r.unchangedSinceApplied(existing, obj, hash) {
+		r.Log.V(1).Info("Obj unchanged, skipping apply", "Name", obj.GetName())
+		skippedAppliesTotal.WithLabelValues(obj.GetKind()).Inc()
+		return nil
+	}
+
 	r.Log.V(1).Info("Applying Obj", "Name", obj.GetName())
-	return apply.ApplyObject(ctx, client, obj)
+	if err := apply.ApplyObject(ctx, sc.client, obj); err != nil {
+		return err
+	}
+	if !sc.dryRun {
+		objectsAppliedTotal.WithLabelValues(obj.GetKind()).Inc()
+		r.setAppliedVersion(obj)
+		if existing == nil {
+			r.eventf(sc.instance, corev1.EventTypeNormal, EventReasonCreated, "Created %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+	}
+	return nil
 }
 
 // deleteObject deletes the desired object against the apiserver,
-func deleteObject(r *ConfigReconciler, ctx context.Context, client client.Client, obj *uns.Unstructured) error {
+func deleteObject(r *ConfigReconciler, ctx context.Context, sc *syncContext, obj *uns.Unstructured) error {
 	name := obj.GetName()
 	namespace := obj.GetNamespace()
 
@@ -431,7 +668,7 @@ func deleteObject(r *ConfigReconciler, ctx context.Context, client client.Client
 	// Get existing
 	existing := &uns.Unstructured{}
 	existing.SetGroupVersionKind(gvk)
-	err := client.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	err := sc.client.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		r.Log.V(1).Info("Obj doesn't exist - do nothing", "objDesc", objDesc)
@@ -441,9 +678,14 @@ func deleteObject(r *ConfigReconciler, ctx context.Context, client client.Client
 		return errors.Wrapf(err, "could not retrieve existing %s", objDesc)
 	}
 
-	if err = client.Delete(ctx, existing); err != nil {
+	if err = sc.client.Delete(ctx, existing); err != nil {
 		return errors.Wrapf(err, "could not delete object %s", objDesc)
 	}
 	r.Log.V(1).Info("Obj successfully deleted", "objDesc", objDesc)
+	if !sc.dryRun {
+		objectsDeletedTotal.WithLabelValues(obj.GetKind()).Inc()
+		r.forgetAppliedVersion(obj)
+		r.eventf(sc.instance, corev1.EventTypeNormal, EventReasonDeleted, "Deleted %s", objDesc)
+	}
 	return nil
 }
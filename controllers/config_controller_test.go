@@ -0,0 +1,355 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	osconfigv1 "github.com/openshift/api/config/v1"
+	osclientset "github.com/openshift/client-go/config/clientset/versioned"
+	configv1client "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/images"
+)
+
+// testInfrastructure is the Infrastructure of a single-stack baremetal
+// cluster.
+func testInfrastructure() *osconfigv1.Infrastructure {
+	return &osconfigv1.Infrastructure{
+		Status: osconfigv1.InfrastructureStatus{
+			PlatformStatus: &osconfigv1.PlatformStatus{
+				Type: osconfigv1.BareMetalPlatformType,
+				BareMetal: &osconfigv1.BareMetalPlatformStatus{
+					APIServerInternalIP: "192.168.111.5",
+					IngressIP:           "192.168.111.4",
+				},
+			},
+		},
+	}
+}
+
+func testImages() *images.Images {
+	return &images.Images{
+		BaremetalRuntimecfg:  "quay.io/openshift/origin-baremetal-runtimecfg:latest",
+		HaproxyRouter:        "quay.io/openshift/origin-haproxy-router:latest",
+		KeepalivedIpfailover: "quay.io/openshift/origin-keepalived-ipfailover:latest",
+		MdnsPublisher:        "quay.io/openshift/origin-mdns-publisher:latest",
+		Coredns:              "quay.io/openshift/origin-coredns:latest",
+	}
+}
+
+func TestRenderManifests(t *testing.T) {
+	t.Parallel()
+
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	instance.Name = ClusterHostedNetServicesConfigCR
+	instance.Spec = clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+		LoadBalancer: clusterhostednetservicesopenshiftiov1beta1.HaLoadBalanceConfig{
+			DefaultIngressHA: "Disable",
+			ApiLoadbalance:   "Enable",
+			ApiVrrpCheck:     "Enable",
+		},
+		DNS: clusterhostednetservicesopenshiftiov1beta1.DnsConfig{
+			NodesResolution: "Disable",
+			ApiResolution:   "Enable",
+			AppsResolution:  "Disable",
+		},
+	}
+
+	r := &ConfigReconciler{HandlerNamespace: "cluster-hosted-net-services"}
+	objs, err := r.RenderManifests(instance, testInfrastructure(), testImages())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	daemonSets := []string{}
+	for _, obj := range objs {
+		if obj.GetLabels()[managedLabel] != ClusterHostedNetServicesConfigCR {
+			t.Errorf("%s %s isn't labelled as managed", obj.GetKind(), obj.GetName())
+		}
+		if obj.GetKind() == "DaemonSet" {
+			daemonSets = append(daemonSets, obj.GetName())
+		}
+	}
+	sort.Strings(daemonSets)
+	expected := []string{"cluster-hosted-coredns", "master-cluster-hosted-haproxy", "master-cluster-hosted-keepalived"}
+	if len(daemonSets) != len(expected) {
+		t.Fatalf("expected DaemonSets %v, got %v", expected, daemonSets)
+	}
+	for i := range expected {
+		if daemonSets[i] != expected[i] {
+			t.Errorf("expected DaemonSets %v, got %v", expected, daemonSets)
+			break
+		}
+	}
+}
+
+func TestRenderHaproxyConfigDualStack(t *testing.T) {
+	t.Parallel()
+
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	instance.Name = ClusterHostedNetServicesConfigCR
+	instance.Spec = clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+		LoadBalancer: clusterhostednetservicesopenshiftiov1beta1.HaLoadBalanceConfig{
+			DefaultIngressHA: "Enable",
+			ApiLoadbalance:   "Enable",
+			ApiVrrpCheck:     "Enable",
+			Unicast:          "Disable",
+		},
+		VIPs: clusterhostednetservicesopenshiftiov1beta1.VipsConfig{
+			AdditionalAPIVIPs:     []string{"fd00::5"},
+			AdditionalIngressVIPs: []string{"fd00::4"},
+		},
+	}
+
+	r := &ConfigReconciler{HandlerNamespace: "cluster-hosted-net-services"}
+	objs, err := r.RenderManifests(instance, testInfrastructure(), testImages())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var haproxyCfg string
+	for _, obj := range objs {
+		if obj.GetKind() == "ConfigMap" && obj.GetName() == "haproxy-template" {
+			haproxyCfg, _, err = unstructured.NestedString(obj.Object, "data", "master-haproxy.conf.tmpl")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if haproxyCfg == "" {
+		t.Fatal("haproxy-template ConfigMap not rendered")
+	}
+
+	for _, bind := range []string{
+		"bind 0.0.0.0:{{ .LBConfig.LbPort }}\n",
+		"bind :::{{ .LBConfig.LbPort }} v6only\n",
+		"bind 0.0.0.0:50936\n",
+		"bind :::50936 v6only\n",
+	} {
+		if !strings.Contains(haproxyCfg, bind) {
+			t.Errorf("expected haproxy.cfg to contain %q, got:\n%s", bind, haproxyCfg)
+		}
+	}
+}
+
+// objectStore is a client.Client keeping the objects it is given as JSON, by
+// kind, namespace and name. Lists are always empty.
+type objectStore struct {
+	client.Client
+	scheme  *runtime.Scheme
+	objects map[string][]byte
+}
+
+func (s *objectStore) key(obj runtime.Object, namespace, name string) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, s.scheme)
+	if err != nil {
+		return "", err
+	}
+	return gvk.Kind + "/" + namespace + "/" + name, nil
+}
+
+func (s *objectStore) store(obj runtime.Object) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	key, err := s.key(obj, accessor.GetNamespace(), accessor.GetName())
+	if err != nil {
+		return err
+	}
+	s.objects[key], err = json.Marshal(obj)
+	return err
+}
+
+func (s *objectStore) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	k, err := s.key(obj, key.Namespace, key.Name)
+	if err != nil {
+		return err
+	}
+	data, ok := s.objects[k]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	return json.Unmarshal(data, obj)
+}
+
+func (s *objectStore) List(context.Context, runtime.Object, ...client.ListOption) error {
+	return nil
+}
+
+func (s *objectStore) Create(_ context.Context, obj runtime.Object, _ ...client.CreateOption) error {
+	return s.store(obj)
+}
+
+func (s *objectStore) Update(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+	return s.store(obj)
+}
+
+func (s *objectStore) Patch(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return s.store(obj)
+}
+
+func (s *objectStore) Delete(context.Context, runtime.Object, ...client.DeleteOption) error {
+	return nil
+}
+
+func (s *objectStore) Status() client.StatusWriter {
+	return s
+}
+
+// stubOSClient serves an Infrastructure and keeps the ClusterOperator it is
+// given
+type stubOSClient struct {
+	osclientset.Interface
+	configv1client.ConfigV1Interface
+	infra *osconfigv1.Infrastructure
+	co    *osconfigv1.ClusterOperator
+}
+
+func (c *stubOSClient) ConfigV1() configv1client.ConfigV1Interface {
+	return c
+}
+
+func (c *stubOSClient) Infrastructures() configv1client.InfrastructureInterface {
+	return infrastructures{client: c}
+}
+
+func (c *stubOSClient) ClusterOperators() configv1client.ClusterOperatorInterface {
+	return clusterOperators{client: c}
+}
+
+type infrastructures struct {
+	configv1client.InfrastructureInterface
+	client *stubOSClient
+}
+
+func (i infrastructures) Get(context.Context, string, metav1.GetOptions) (*osconfigv1.Infrastructure, error) {
+	return i.client.infra.DeepCopy(), nil
+}
+
+type clusterOperators struct {
+	configv1client.ClusterOperatorInterface
+	client *stubOSClient
+}
+
+func (o clusterOperators) Get(context.Context, string, metav1.GetOptions) (*osconfigv1.ClusterOperator, error) {
+	return o.client.co.DeepCopy(), nil
+}
+
+func (o clusterOperators) UpdateStatus(_ context.Context, co *osconfigv1.ClusterOperator, _ metav1.UpdateOptions) (*osconfigv1.ClusterOperator, error) {
+	o.client.co = co.DeepCopy()
+	return co, nil
+}
+
+func TestReconcileReportsSplitBrainAndUnreachableVip(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := clusterhostednetservicesopenshiftiov1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store := &objectStore{scheme: scheme, objects: map[string][]byte{}}
+
+	imagesJSON, err := json.Marshal(testImages())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	imagesConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-hosted-net-services-operator-images", Namespace: "cluster-hosted-net-services-operator"},
+		Data:       map[string]string{images.ImagesJSONKey: string(imagesJSON)},
+	}
+	now := time.Now()
+	severalMastersSince := metav1.NewTime(now.Add(-time.Minute))
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterHostedNetServicesConfigCR, Namespace: "cluster-hosted-net-services-operator"},
+		Spec: clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+			LoadBalancer: clusterhostednetservicesopenshiftiov1beta1.HaLoadBalanceConfig{
+				DefaultIngressHA: "Enable",
+				ApiLoadbalance:   "Enable",
+				ApiVrrpCheck:     "Enable",
+				Unicast:          "Disable",
+			},
+			DNS: clusterhostednetservicesopenshiftiov1beta1.DnsConfig{
+				NodesResolution: "Enable",
+				ApiResolution:   "Enable",
+				AppsResolution:  "Enable",
+			},
+		},
+		Status: clusterhostednetservicesopenshiftiov1beta1.ConfigStatus{
+			VRRPInstances: []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus{
+				{Name: "ostest_API", Masters: []string{"master-0", "master-1"}, SeveralMastersSince: &severalMastersSince},
+			},
+			VipProbes: []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus{
+				{VIP: "api", Address: "192.168.111.5", Reachable: true},
+				{VIP: "ingress", Address: "192.168.111.4", Reachable: false},
+			},
+		},
+	}
+	for _, obj := range []runtime.Object{imagesConfigMap, instance} {
+		if err := store.store(obj); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	infra := testInfrastructure()
+	infra.Status.Platform = osconfigv1.BareMetalPlatformType
+	osClient := &stubOSClient{infra: infra, co: &osconfigv1.ClusterOperator{ObjectMeta: metav1.ObjectMeta{Name: clusterOperatorName}}}
+
+	r := &ConfigReconciler{
+		Client:           store,
+		Log:              log.NullLogger{},
+		Scheme:           scheme,
+		OSClient:         osClient,
+		Namespace:        "cluster-hosted-net-services-operator",
+		HandlerNamespace: "cluster-hosted-net-services",
+		ImagesConfigMap:  imagesConfigMap.Name,
+	}
+	result, err := r.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != rolloutResync {
+		t.Errorf("expected a requeue after %s, got %v", rolloutResync, result)
+	}
+
+	expected := "VRRP instances with several masters: ostest_API (master-0, master-1); VIPs unreachable: ingress 192.168.111.4"
+	var degraded *osconfigv1.ClusterOperatorStatusCondition
+	for i := range osClient.co.Status.Conditions {
+		if osClient.co.Status.Conditions[i].Type == osconfigv1.OperatorDegraded {
+			degraded = &osClient.co.Status.Conditions[i]
+		}
+	}
+	if degraded == nil || degraded.Status != osconfigv1.ConditionTrue || degraded.Reason != string(ReasonSplitBrain) || degraded.Message != expected {
+		t.Errorf("expected the ClusterOperator to be Degraded with %q, got %+v", expected, degraded)
+	}
+
+	updated := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	if err := store.Get(context.Background(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, updated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, ConditionDegraded)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Message != expected {
+		t.Errorf("expected the Config to be Degraded with %q, got %+v", expected, condition)
+	}
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const (
+	// ConditionAvailable is True when every enabled component has ready handler pods
+	ConditionAvailable = "Available"
+
+	// ConditionProgressing is True while handler pods of enabled components aren't all ready
+	ConditionProgressing = "Progressing"
+
+	// ConditionDegraded is True when the last sync of a component failed, when
+	// a VRRP instance has several masters or when a VIP is unreachable
+	ConditionDegraded = "Degraded"
+)
+
+// updateConfigStatus records the state of every component, along with the
+// overall conditions, in the Config status.
+func (r *ConfigReconciler) updateConfigStatus(instance *clusterhostednetservicesopenshiftiov1beta1.Config, syncErrors map[string]error) error {
+	ctx := context.Background()
+	orig := instance.DeepCopy()
+
+	statuses := []clusterhostednetservicesopenshiftiov1beta1.ComponentStatus{}
+	failed := []string{}
+	notReady := []string{}
+	unavailable := []string{}
+	for _, c := range components {
+		name := c.Name()
+		status := clusterhostednetservicesopenshiftiov1beta1.ComponentStatus{
+			Name:    name,
+			Enabled: c.Enabled(&instance.Spec),
+		}
+		if err := syncErrors[name]; err != nil {
+			status.LastError = err.Error()
+			failed = append(failed, name)
+		}
+
+		desired, ready, err := c.Ready(ctx, r.Client, r.HandlerNamespace)
+		if err != nil {
+			return err
+		}
+		status.DesiredPods = desired
+		status.ReadyPods = ready
+		componentDesiredPods.WithLabelValues(name).Set(float64(desired))
+		componentReadyPods.WithLabelValues(name).Set(float64(ready))
+		if status.Enabled {
+			componentEnabled.WithLabelValues(name).Set(1)
+		} else {
+			componentEnabled.WithLabelValues(name).Set(0)
+		}
+
+		if status.Enabled && status.ReadyPods < status.DesiredPods {
+			notReady = append(notReady, name)
+			if status.ReadyPods == 0 {
+				unavailable = append(unavailable, name)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	instance.Status.Components = statuses
+	instance.Status.ObservedGeneration = instance.Generation
+	// The changes of a previous dry-run are either made or outdated
+	instance.Status.DryRun = nil
+
+	if len(failed) > 0 {
+		setConfigCondition(instance, ConditionDegraded, metav1.ConditionTrue, "SyncFailed",
+			fmt.Sprintf("failed to sync %s", strings.Join(failed, ", ")))
+	} else if reason, msg := vipsDegraded(&instance.Status, time.Now()); reason != "" {
+		setConfigCondition(instance, ConditionDegraded, metav1.ConditionTrue, string(reason), msg)
+	} else {
+		setConfigCondition(instance, ConditionDegraded, metav1.ConditionFalse, "AsExpected", "")
+	}
+	if len(notReady) > 0 {
+		setConfigCondition(instance, ConditionProgressing, metav1.ConditionTrue, "PodsNotReady",
+			fmt.Sprintf("handler pods not ready for %s", strings.Join(notReady, ", ")))
+	} else {
+		setConfigCondition(instance, ConditionProgressing, metav1.ConditionFalse, "AsExpected", "")
+	}
+	if len(unavailable) > 0 {
+		setConfigCondition(instance, ConditionAvailable, metav1.ConditionFalse, "NoPodsReady",
+			fmt.Sprintf("no handler pod ready for %s", strings.Join(unavailable, ", ")))
+	} else {
+		setConfigCondition(instance, ConditionAvailable, metav1.ConditionTrue, "AsExpected", "")
+	}
+
+	return r.Client.Status().Patch(ctx, instance, client.MergeFrom(orig))
+}
+
+// vipsDegraded returns the reason and message of the Degraded condition for
+// the VRRP instances in split brain and the unreachable VIPs, which are both
+// listed, or an empty reason when there is none.
+func vipsDegraded(status *clusterhostednetservicesopenshiftiov1beta1.ConfigStatus, now time.Time) (StatusReason, string) {
+	var reason StatusReason
+	msgs := []string{}
+	if split := splitBrains(status.VRRPInstances, now); len(split) > 0 {
+		reason = ReasonSplitBrain
+		msgs = append(msgs, fmt.Sprintf("VRRP instances with several masters: %s", strings.Join(split, ", ")))
+	}
+	if unreachable := unreachableVips(status.VipProbes); len(unreachable) > 0 {
+		if reason == "" {
+			reason = ReasonVipUnreachable
+		}
+		msgs = append(msgs, fmt.Sprintf("VIPs unreachable: %s", strings.Join(unreachable, ", ")))
+	}
+	return reason, strings.Join(msgs, "; ")
+}
+
+func setConfigCondition(instance *clusterhostednetservicesopenshiftiov1beta1.Config, conditionType string,
+	status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: instance.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const configValidatingWebhookPath = "/validate-cluster-hosted-net-services-openshift-io-v1beta1-config"
+
+// +kubebuilder:webhook:path=/validate-cluster-hosted-net-services-openshift-io-v1beta1-config,mutating=false,failurePolicy=fail,sideEffects=None,groups=cluster-hosted-net-services.openshift.io,resources=configs,verbs=create;update,versions=v1beta1,name=vconfig.cluster-hosted-net-services.openshift.io,admissionReviewVersions=v1beta1
+
+// ConfigValidator rejects Config objects the operator can't act on: any Config
+// other than the singleton one, and specs enabling services that depend on
+// disabled ones.
+type ConfigValidator struct {
+	// Client has to read Configs from every namespace, not only the cached ones
+	Client client.Reader
+	// Namespace is the namespace of the operator and of the Config singleton
+	Namespace string
+	decoder   *admission.Decoder
+}
+
+func (v *ConfigValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	config := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	if err := v.decoder.Decode(req, config); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// The name and namespace of a Config can't change, they are only checked
+	// on creation
+	if req.Operation == admissionv1beta1.Create {
+		if config.Name != ClusterHostedNetServicesConfigCR || config.Namespace != v.Namespace {
+			return admission.Denied(fmt.Sprintf("only a single Config named %q, in namespace %q, is supported",
+				ClusterHostedNetServicesConfigCR, v.Namespace))
+		}
+		configs := &clusterhostednetservicesopenshiftiov1beta1.ConfigList{}
+		if err := v.Client.List(ctx, configs); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		for _, existing := range configs.Items {
+			if existing.Namespace != config.Namespace || existing.Name != config.Name {
+				return admission.Denied(fmt.Sprintf("Config %s/%s already exists, only a single Config is supported",
+					existing.Namespace, existing.Name))
+			}
+		}
+	}
+
+	if err := ValidateConfigSpec(&config.Spec); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *ConfigValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func (v *ConfigValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(configValidatingWebhookPath, &webhook.Admission{Handler: v})
+	return nil
+}
+
+// ValidateConfigSpec rejects specs enabling a service that relies on a
+// disabled one. The keepalived API VRRP check tracks the HAProxy health, so it
+// requires ApiLoadbalance, and keepalived only holds the Ingress VIP when
+// DefaultIngressHA is enabled. The API records may resolve to an API VIP held
+// outside of the cluster. The VRRP instances of the additional VIPs have no
+// unicast peers, so they require multicast.
+func ValidateConfigSpec(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) error {
+	apiVip := spec.LoadBalancer.ApiLoadbalance == "Enable"
+	ingressVip := spec.LoadBalancer.DefaultIngressHA == "Enable"
+
+	if spec.LoadBalancer.ApiVrrpCheck == "Enable" && !apiVip {
+		return fmt.Errorf("loadbalancer.apivrrpcheck requires loadbalancer.apiloadbalance, the API VRRP check tracks the HAProxy health")
+	}
+	if spec.DNS.AppsResolution == "Enable" && !ingressVip {
+		return fmt.Errorf("dns.appsresolution requires loadbalancer.defaultingressha, the apps records resolve to the Ingress VIP")
+	}
+	if len(spec.VIPs.AdditionalAPIVIPs) > 0 && !apiVip {
+		return fmt.Errorf("vips.additionalapivips requires loadbalancer.apiloadbalance")
+	}
+	if len(spec.VIPs.AdditionalIngressVIPs) > 0 && !ingressVip {
+		return fmt.Errorf("vips.additionalingressvips requires loadbalancer.defaultingressha")
+	}
+	if len(spec.VIPs.AdditionalAPIVIPs)+len(spec.VIPs.AdditionalIngressVIPs) > 0 && spec.LoadBalancer.Unicast == "Enable" {
+		return fmt.Errorf("additional VIPs require loadbalancer.unicast to be disabled, the unicast peers of the secondary address family aren't known")
+	}
+
+	if err := validateAdditionalVips("vips.additionalapivips", spec.VIPs.AdditionalAPIVIPs); err != nil {
+		return err
+	}
+	return validateAdditionalVips("vips.additionalingressvips", spec.VIPs.AdditionalIngressVIPs)
+}
+
+func validateAdditionalVips(field string, vips []string) error {
+	if len(vips) > 1 {
+		return fmt.Errorf("%s holds at most one VIP, of the secondary address family", field)
+	}
+	for _, vip := range vips {
+		if net.ParseIP(vip) == nil {
+			return fmt.Errorf("%s: %q is not a valid IP address", field, vip)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// configLister lists the given Configs
+type configLister struct {
+	client.Reader
+	configs []clusterhostednetservicesopenshiftiov1beta1.Config
+}
+
+func (c configLister) List(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+	list.(*clusterhostednetservicesopenshiftiov1beta1.ConfigList).Items = c.configs
+	return nil
+}
+
+func TestConfigValidatorHandle(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := clusterhostednetservicesopenshiftiov1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := func(namespace, name string) clusterhostednetservicesopenshiftiov1beta1.Config {
+		return clusterhostednetservicesopenshiftiov1beta1.Config{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: clusterhostednetservicesopenshiftiov1beta1.GroupVersion.String(),
+				Kind:       "Config",
+			},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		operation       admissionv1beta1.Operation
+		config          clusterhostednetservicesopenshiftiov1beta1.Config
+		existing        []clusterhostednetservicesopenshiftiov1beta1.Config
+		expectedAllowed bool
+	}{
+		{
+			name:            "singleton",
+			operation:       admissionv1beta1.Create,
+			config:          config("cluster-hosted-net-services-operator", ClusterHostedNetServicesConfigCR),
+			expectedAllowed: true,
+		},
+		{
+			name:      "wrong name",
+			operation: admissionv1beta1.Create,
+			config:    config("cluster-hosted-net-services-operator", "other"),
+		},
+		{
+			name:      "wrong namespace",
+			operation: admissionv1beta1.Create,
+			config:    config("default", ClusterHostedNetServicesConfigCR),
+		},
+		{
+			name:      "second Config",
+			operation: admissionv1beta1.Create,
+			config:    config("cluster-hosted-net-services-operator", ClusterHostedNetServicesConfigCR),
+			existing:  []clusterhostednetservicesopenshiftiov1beta1.Config{config("default", ClusterHostedNetServicesConfigCR)},
+		},
+		{
+			name:            "update",
+			operation:       admissionv1beta1.Update,
+			config:          config("cluster-hosted-net-services-operator", ClusterHostedNetServicesConfigCR),
+			existing:        []clusterhostednetservicesopenshiftiov1beta1.Config{config("cluster-hosted-net-services-operator", ClusterHostedNetServicesConfigCR)},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := json.Marshal(tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			v := &ConfigValidator{
+				Client:    configLister{configs: tc.existing},
+				Namespace: "cluster-hosted-net-services-operator",
+			}
+			if err := v.InjectDecoder(decoder); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp := v.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+			if resp.Allowed != tc.expectedAllowed {
+				t.Errorf("expected allowed %t, got %t: %v", tc.expectedAllowed, resp.Allowed, resp.Result)
+			}
+		})
+	}
+}
+
+func TestValidateConfigSpec(t *testing.T) {
+	t.Parallel()
+
+	// The spec once defaulted by the CRD schema
+	defaultSpec := clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+		LoadBalancer: clusterhostednetservicesopenshiftiov1beta1.HaLoadBalanceConfig{
+			DefaultIngressHA: "Enable",
+			ApiLoadbalance:   "Enable",
+			ApiVrrpCheck:     "Enable",
+			Unicast:          "Disable",
+		},
+		DNS: clusterhostednetservicesopenshiftiov1beta1.DnsConfig{
+			NodesResolution: "Enable",
+			ApiResolution:   "Enable",
+			AppsResolution:  "Enable",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		update        func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec)
+		expectedError bool
+	}{
+		{
+			name:   "default",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {},
+		},
+		{
+			name: "everything disabled",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.ApiLoadbalance = "Disable"
+				spec.LoadBalancer.ApiVrrpCheck = "Disable"
+				spec.LoadBalancer.DefaultIngressHA = "Disable"
+				spec.LoadBalancer.Unicast = "Disable"
+				spec.DNS.NodesResolution = "Disable"
+				spec.DNS.ApiResolution = "Disable"
+				spec.DNS.AppsResolution = "Disable"
+			},
+		},
+		{
+			name: "API VRRP check without API load balancer",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.ApiLoadbalance = "Disable"
+			},
+			expectedError: true,
+		},
+		{
+			name: "API resolution to an external API VIP",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.ApiLoadbalance = "Disable"
+				spec.LoadBalancer.ApiVrrpCheck = "Disable"
+			},
+		},
+		{
+			name: "API load balancer without VRRP check",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.ApiVrrpCheck = "Disable"
+			},
+		},
+		{
+			name: "apps resolution without Ingress VIP",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.DefaultIngressHA = "Disable"
+			},
+			expectedError: true,
+		},
+		{
+			name: "additional Ingress VIP without Ingress VIP",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.DefaultIngressHA = "Disable"
+				spec.DNS.AppsResolution = "Disable"
+				spec.VIPs.AdditionalIngressVIPs = []string{"fd00::11"}
+			},
+			expectedError: true,
+		},
+		{
+			name: "additional VIPs",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.VIPs.AdditionalAPIVIPs = []string{"fd00::10"}
+				spec.VIPs.AdditionalIngressVIPs = []string{"fd00::11"}
+			},
+		},
+		{
+			name: "additional VIP with unicast",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.LoadBalancer.Unicast = "Enable"
+				spec.VIPs.AdditionalAPIVIPs = []string{"fd00::10"}
+			},
+			expectedError: true,
+		},
+		{
+			name: "several additional API VIPs",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.VIPs.AdditionalAPIVIPs = []string{"fd00::10", "fd00::12"}
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid additional VIP",
+			update: func(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+				spec.VIPs.AdditionalIngressVIPs = []string{"fd00::zz"}
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := defaultSpec.DeepCopy()
+			tc.update(spec)
+			err := ValidateConfigSpec(spec)
+			if tc.expectedError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
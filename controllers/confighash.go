@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// configHashAnnotation is set on the pod template of the handler DaemonSets to
+// the hash of the ConfigMaps they mount. The ConfigMaps are mounted through
+// subPath, which never refreshes, so a change of their content has to roll
+// the pods out.
+const configHashAnnotation = "cluster-hosted-net-services.openshift.io/config-hash"
+
+// stampConfigHashes sets the configHashAnnotation of the DaemonSets, for the
+// ConfigMaps among objs they mount.
+func stampConfigHashes(objs []*uns.Unstructured) error {
+	configMaps := map[string]*uns.Unstructured{}
+	for _, obj := range objs {
+		if obj.GetKind() == "ConfigMap" {
+			configMaps[obj.GetNamespace()+"/"+obj.GetName()] = obj
+		}
+	}
+
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" {
+			continue
+		}
+		mounted, err := mountedConfigMaps(obj)
+		if err != nil {
+			return err
+		}
+		contents := map[string]interface{}{}
+		for _, name := range mounted {
+			if cm, ok := configMaps[obj.GetNamespace()+"/"+name]; ok {
+				contents[name] = map[string]interface{}{
+					"data":       cm.Object["data"],
+					"binaryData": cm.Object["binaryData"],
+				}
+			}
+		}
+		if len(contents) == 0 {
+			continue
+		}
+
+		// Maps are marshalled with sorted keys, the hash is stable
+		data, err := json.Marshal(contents)
+		if err != nil {
+			return errors.Wrapf(err, "failed hashing the ConfigMaps of %s", obj.GetName())
+		}
+		annotations, _, err := uns.NestedStringMap(obj.Object, "spec", "template", "metadata", "annotations")
+		if err != nil {
+			return errors.Wrapf(err, "invalid pod template annotations in %s", obj.GetName())
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[configHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(data))
+		if err := uns.SetNestedStringMap(obj.Object, annotations, "spec", "template", "metadata", "annotations"); err != nil {
+			return errors.Wrapf(err, "failed setting the pod template annotations of %s", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// mountedConfigMaps returns the names of the ConfigMaps mounted by the pods of
+// a DaemonSet.
+func mountedConfigMaps(ds *uns.Unstructured) ([]string, error) {
+	volumes, _, err := uns.NestedSlice(ds.Object, "spec", "template", "spec", "volumes")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid volumes in %s", ds.GetName())
+	}
+	names := []string{}
+	for _, volume := range volumes {
+		v, ok := volume.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, found, err := uns.NestedString(v, "configMap", "name")
+		if err != nil || !found {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
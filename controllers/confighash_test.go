@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"testing"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestStampConfigHashes(t *testing.T) {
+	t.Parallel()
+
+	configMap := func(name, template string) *uns.Unstructured {
+		return &uns.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "cluster-hosted-net-services"},
+			"data":       map[string]interface{}{"config.tmpl": template},
+		}}
+	}
+	daemonSet := func(name, configMapName string) *uns.Unstructured {
+		return &uns.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "DaemonSet",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "cluster-hosted-net-services"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"volumes": []interface{}{
+							map[string]interface{}{"name": "resource-dir", "configMap": map[string]interface{}{"name": configMapName}},
+							map[string]interface{}{"name": "kubeconfig", "hostPath": map[string]interface{}{"path": "/etc/kubernetes"}},
+						},
+					},
+				},
+			},
+		}}
+	}
+	hash := func(template string) string {
+		ds := daemonSet("cluster-hosted-coredns", "coredns-template")
+		if err := stampConfigHashes([]*uns.Unstructured{configMap("coredns-template", template), ds}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		value, _, _ := uns.NestedString(ds.Object, "spec", "template", "metadata", "annotations", configHashAnnotation)
+		return value
+	}
+
+	first := hash("api.{{ .Cluster.Domain }}")
+	if first == "" {
+		t.Fatalf("expected the DaemonSet to carry the config hash")
+	}
+	if hash("api.{{ .Cluster.Domain }}") != first {
+		t.Errorf("expected the same template to give the same hash")
+	}
+	if hash("api-int.{{ .Cluster.Domain }}") == first {
+		t.Errorf("expected a template change to change the hash")
+	}
+
+	unrelated := daemonSet("cluster-hosted-mdns", "mdns-template")
+	if err := stampConfigHashes([]*uns.Unstructured{configMap("coredns-template", "api"), unrelated}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, found, _ := uns.NestedString(unrelated.Object, "spec", "template", "metadata", "annotations", configHashAnnotation); found {
+		t.Errorf("expected no hash on a DaemonSet not mounting the ConfigMap")
+	}
+}
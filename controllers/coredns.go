@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// corednsComponent resolves the node names, learnt over mDNS, and the api,
+// api-int and *.apps names to the VIPs. It's only synced once the VIPs and the
+// mDNS publishers are rolled out, so that it never resolves to a VIP nobody
+// holds yet.
+type corednsComponent struct {
+	daemonSetComponent
+}
+
+func newCoreDNSComponent() *corednsComponent {
+	return &corednsComponent{daemonSetComponent{
+		name:         "coredns",
+		daemonSets:   []string{"cluster-hosted-coredns"},
+		dependencies: []string{"keepalived", "mdns"},
+	}}
+}
+
+func (c *corednsComponent) Enabled(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) bool {
+	return spec.DNS.NodesResolution == "Enable" || spec.DNS.ApiResolution == "Enable" || spec.DNS.AppsResolution == "Enable"
+}
+
+func (c *corednsComponent) AddRenderData(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, sc *syncContext, data *render.RenderData) {
+	data.Data["CorednsImage"] = sc.images.Coredns
+	data.Data["EnableNodesResolution"] = spec.DNS.NodesResolution == "Enable"
+	data.Data["EnableAPIResolution"] = spec.DNS.ApiResolution == "Enable"
+	data.Data["EnableAppsResolution"] = spec.DNS.AppsResolution == "Enable"
+}
+
+func (c *corednsComponent) ManifestDirs(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) []manifestDir {
+	return []manifestDir{
+		{Name: "coredns-configmap"},
+		{Name: "coredns-daemonset"},
+	}
+}
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	osconfigv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const (
+	// corednsPort is the port the CoreDNS handlers listen on, on the node
+	// addresses
+	corednsPort = "53"
+
+	// appsCheckLabel is the name looked up under the apps domain, any name
+	// of the domain resolves to the Ingress VIPs
+	appsCheckLabel = "cluster-hosted-dns-check"
+)
+
+// expectedDNSRecords returns the addresses the CoreDNS handlers should resolve
+// the api, api-int and *.apps names to, keyed by fully qualified name. The
+// cluster domain is the one of the API server URL.
+func expectedDNSRecords(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, infra *osconfigv1.Infrastructure, apiVips, ingressVips []string) (map[string][]string, error) {
+	records := map[string][]string{}
+	if spec.DNS.ApiResolution != "Enable" && spec.DNS.AppsResolution != "Enable" {
+		return records, nil
+	}
+
+	apiURL, err := url.Parse(infra.Status.APIServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API server URL %q: %v", infra.Status.APIServerURL, err)
+	}
+	clusterDomain := strings.TrimPrefix(apiURL.Hostname(), "api.")
+	if clusterDomain == apiURL.Hostname() || clusterDomain == "" {
+		return nil, fmt.Errorf("unable to determine the cluster domain from the API server URL %q", infra.Status.APIServerURL)
+	}
+
+	if spec.DNS.ApiResolution == "Enable" {
+		records["api."+clusterDomain+"."] = apiVips
+		records["api-int."+clusterDomain+"."] = apiVips
+	}
+	if spec.DNS.AppsResolution == "Enable" {
+		records[appsCheckLabel+".apps."+clusterDomain+"."] = ingressVips
+	}
+	return records, nil
+}
+
+// checkNodeDNS looks the expected records up on a DNS server and describes
+// those missing or resolving to other addresses.
+func checkNodeDNS(ctx context.Context, server string, records map[string][]string, timeout time.Duration) []string {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: timeout}
+			return d.DialContext(ctx, network, server)
+		},
+	}
+
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := []string{}
+	for _, name := range names {
+		expected := []string{}
+		for _, address := range records[name] {
+			expected = append(expected, net.ParseIP(address).String())
+		}
+		sort.Strings(expected)
+
+		lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+		addrs, err := resolver.LookupIPAddr(lookupCtx, name)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: expected %s, lookup failed: %v", strings.TrimSuffix(name, "."), strings.Join(expected, ","), err))
+			continue
+		}
+		resolved := []string{}
+		for _, addr := range addrs {
+			resolved = append(resolved, addr.IP.String())
+		}
+		sort.Strings(resolved)
+
+		if strings.Join(resolved, ",") != strings.Join(expected, ",") {
+			errs = append(errs, fmt.Sprintf("%s: expected %s, got %s", strings.TrimSuffix(name, "."), strings.Join(expected, ","), strings.Join(resolved, ",")))
+		}
+	}
+	return errs
+}
+
+// checkDNSRecords verifies the records resolved by the CoreDNS handler of
+// every node running a ready one, and returns the nodes failing to.
+func (p *VipProber) checkDNSRecords(ctx context.Context, records map[string][]string, timeout time.Duration) ([]clusterhostednetservicesopenshiftiov1beta1.NodeDNSRecordErrors, error) {
+	dnsRecordErrors.Reset()
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	nodeErrors := []clusterhostednetservicesopenshiftiov1beta1.NodeDNSRecordErrors{}
+	for _, dsName := range newCoreDNSComponent().DaemonSets() {
+		ds := &appsv1.DaemonSet{}
+		err := p.Client.Get(ctx, types.NamespacedName{Name: dsName, Namespace: p.HandlerNamespace}, ds)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		pods := &corev1.PodList{}
+		if err := p.Client.List(ctx, pods, client.InNamespace(ds.Namespace),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods.Items {
+			// The handlers run on the host network, the pod address is the
+			// node one
+			if pod.Status.PodIP == "" || !podReady(&pod) {
+				continue
+			}
+			errs := checkNodeDNS(ctx, net.JoinHostPort(pod.Status.PodIP, corednsPort), records, timeout)
+			dnsRecordErrors.WithLabelValues(pod.Spec.NodeName).Set(float64(len(errs)))
+			if len(errs) > 0 {
+				nodeErrors = append(nodeErrors, clusterhostednetservicesopenshiftiov1beta1.NodeDNSRecordErrors{
+					Node:   pod.Spec.NodeName,
+					Errors: errs,
+				})
+			}
+		}
+	}
+
+	if len(nodeErrors) == 0 {
+		return nil, nil
+	}
+	sort.Slice(nodeErrors, func(i, j int) bool {
+		return nodeErrors[i].Node < nodeErrors[j].Node
+	})
+	return nodeErrors, nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	osconfigv1 "github.com/openshift/api/config/v1"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const (
+	dnsTypeA    = 1
+	dnsTypeAAAA = 28
+)
+
+// startDNSServer runs an in-process DNS server answering the A and AAAA
+// queries of the given records, standing in for the CoreDNS of a node. It
+// returns the address of the server and the function stopping it.
+func startDNSServer(t *testing.T, records map[string][]string) (string, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := dnsAnswer(buf[:n], records); resp != nil {
+				conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String(), func() { conn.Close() }
+}
+
+// dnsAnswer builds the response to a single question query.
+func dnsAnswer(query []byte, records map[string][]string) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+	labels := []string{}
+	offset := 12
+	for offset < len(query) && query[offset] != 0 {
+		length := int(query[offset])
+		if offset+1+length > len(query) {
+			return nil
+		}
+		labels = append(labels, string(query[offset+1:offset+1+length]))
+		offset += 1 + length
+	}
+	offset++
+	if offset+4 > len(query) {
+		return nil
+	}
+	name := strings.ToLower(strings.Join(labels, ".")) + "."
+	qtype := binary.BigEndian.Uint16(query[offset : offset+2])
+	question := query[12 : offset+4]
+
+	addresses, known := records[name]
+	answers := [][]byte{}
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		rdata := ip.To4()
+		rtype := uint16(dnsTypeA)
+		if rdata == nil {
+			rdata = ip.To16()
+			rtype = dnsTypeAAAA
+		}
+		if rtype != qtype {
+			continue
+		}
+		// Name pointer to the question, type, class IN, TTL and data
+		answer := []byte{0xc0, 12}
+		answer = append(answer, byte(rtype>>8), byte(rtype), 0, 1, 0, 0, 0, 60, 0, byte(len(rdata)))
+		answers = append(answers, append(answer, rdata...))
+	}
+
+	// Response, authoritative, recursion desired and available
+	flags := uint16(0x8580)
+	if !known {
+		// NXDOMAIN
+		flags |= 3
+	}
+	resp := make([]byte, 12)
+	copy(resp[0:2], query[0:2])
+	binary.BigEndian.PutUint16(resp[2:4], flags)
+	binary.BigEndian.PutUint16(resp[4:6], 1)
+	binary.BigEndian.PutUint16(resp[6:8], uint16(len(answers)))
+	resp = append(resp, question...)
+	for _, answer := range answers {
+		resp = append(resp, answer...)
+	}
+	return resp
+}
+
+func TestExpectedDNSRecords(t *testing.T) {
+	t.Parallel()
+
+	infra := &osconfigv1.Infrastructure{}
+	infra.Status.APIServerURL = "https://api.ostest.test.metalkube.org:6443"
+	spec := &clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+		DNS: clusterhostednetservicesopenshiftiov1beta1.DnsConfig{
+			ApiResolution:  "Enable",
+			AppsResolution: "Disable",
+		},
+	}
+
+	records, err := expectedDNSRecords(spec, infra, []string{"192.168.111.5", "fd00::5"}, []string{"192.168.111.4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string][]string{
+		"api.ostest.test.metalkube.org.":     {"192.168.111.5", "fd00::5"},
+		"api-int.ostest.test.metalkube.org.": {"192.168.111.5", "fd00::5"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("expected %v, got %v", expected, records)
+	}
+
+	infra.Status.APIServerURL = "https://192.168.111.5:6443"
+	if _, err := expectedDNSRecords(spec, infra, nil, nil); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestCheckNodeDNS(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string][]string{
+		"api.ostest.test.metalkube.org.":                           {"192.168.111.5", "fd00::5"},
+		"api-int.ostest.test.metalkube.org.":                       {"192.168.111.5", "fd00::5"},
+		"cluster-hosted-dns-check.apps.ostest.test.metalkube.org.": {"192.168.111.4"},
+	}
+
+	testCases := []struct {
+		name           string
+		served         map[string][]string
+		expectedErrors []string
+	}{
+		{
+			name:   "every record served",
+			served: expected,
+		},
+		{
+			name: "wrong and missing records",
+			served: map[string][]string{
+				"api.ostest.test.metalkube.org.":                           {"192.168.111.5"},
+				"api-int.ostest.test.metalkube.org.":                       {"192.168.111.5", "fd00::5"},
+				"cluster-hosted-dns-check.apps.ostest.test.metalkube.org.": {"192.168.111.40"},
+			},
+			expectedErrors: []string{
+				"api.ostest.test.metalkube.org: expected 192.168.111.5,fd00::5, got 192.168.111.5",
+				"cluster-hosted-dns-check.apps.ostest.test.metalkube.org: expected 192.168.111.4, got 192.168.111.40",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, stop := startDNSServer(t, tc.served)
+			defer stop()
+			errs := checkNodeDNS(context.Background(), server, expected, time.Second)
+			if len(errs) != len(tc.expectedErrors) {
+				t.Fatalf("expected errors %v, got %v", tc.expectedErrors, errs)
+			}
+			for i := range errs {
+				if errs[i] != tc.expectedErrors[i] {
+					t.Errorf("expected error %q, got %q", tc.expectedErrors[i], errs[i])
+				}
+			}
+		})
+	}
+
+	// A record the server doesn't know
+	server, stop := startDNSServer(t, map[string][]string{})
+	defer stop()
+	errs := checkNodeDNS(context.Background(), server, map[string][]string{"api.ostest.test.metalkube.org.": {"192.168.111.5"}}, time.Second)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "api.ostest.test.metalkube.org: expected 192.168.111.5, lookup failed") {
+		t.Errorf("expected a failed lookup, got %v", errs)
+	}
+}
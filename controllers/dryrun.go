@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// DryRunAnnotation set to "true" on the Config makes the operator compute the
+// changes a reconcile would make to the handler objects, using server-side
+// dry-run, instead of making them.
+const DryRunAnnotation = "cluster-hosted-net-services.openshift.io/dry-run"
+
+// dryRunClient sends every write as a server-side dry-run and records the
+// changes that went through.
+type dryRunClient struct {
+	client.Client
+	changes []string
+}
+
+func newDryRunClient(c client.Client) *dryRunClient {
+	return &dryRunClient{Client: client.NewDryRunClient(c)}
+}
+
+func (c *dryRunClient) record(verb string, obj runtime.Object) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	name := ""
+	if accessor, err := meta.Accessor(obj); err == nil {
+		name = accessor.GetName()
+		if accessor.GetNamespace() != "" {
+			name = accessor.GetNamespace() + "/" + name
+		}
+	}
+	c.changes = append(c.changes, fmt.Sprintf("%s %s %s", verb, kind, name))
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record("create", obj)
+	return nil
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record("update", obj)
+	return nil
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.record("update", obj)
+	return nil
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record("delete", obj)
+	return nil
+}
+
+// isDryRun tells whether the reconcile of the Config must not change the
+// handler objects.
+func (r *ConfigReconciler) isDryRun(instance *clusterhostednetservicesopenshiftiov1beta1.Config) bool {
+	return r.DryRun || instance.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+// dryRunSync runs the sync of every component with a dry-run client, instead
+// of waiting for the components to be rolled out one after the other, and
+// records the changes in the Config status.
+func (r *ConfigReconciler) dryRunSync(instance *clusterhostednetservicesopenshiftiov1beta1.Config, sc *syncContext) error {
+	dc := newDryRunClient(r.Client)
+	sc.client = dc
+	sc.dryRun = true
+
+	syncErrors := []string{}
+	if err := r.syncNamespace(instance, sc); err != nil {
+		syncErrors = append(syncErrors, errors.Wrap(err, "failed applying Namespace").Error())
+	}
+	if err := r.syncRBAC(instance, sc); err != nil {
+		syncErrors = append(syncErrors, errors.Wrap(err, "failed applying RBAC").Error())
+	}
+	for _, c := range components {
+		if err := r.syncComponent(instance, sc, c); err != nil {
+			syncErrors = append(syncErrors, errors.Wrapf(err, "failed applying %s", c.Name()).Error())
+		}
+	}
+	r.Log.Info("dry-run changes", "changes", dc.changes, "errors", syncErrors)
+
+	return r.updateDryRunStatus(instance, dc.changes, syncErrors, metav1.Now())
+}
+
+// updateDryRunStatus records the dry-run outcome in the Config status, keeping
+// the time of the previous one when nothing changed.
+func (r *ConfigReconciler) updateDryRunStatus(instance *clusterhostednetservicesopenshiftiov1beta1.Config, changes, syncErrors []string, now metav1.Time) error {
+	orig := instance.DeepCopy()
+
+	dryRun := &clusterhostednetservicesopenshiftiov1beta1.DryRunStatus{
+		ObservedGeneration: instance.Generation,
+		Time:               now,
+	}
+	if len(changes) > 0 {
+		dryRun.Changes = changes
+	}
+	if len(syncErrors) > 0 {
+		dryRun.Errors = syncErrors
+	}
+	if last := instance.Status.DryRun; last != nil && last.ObservedGeneration == dryRun.ObservedGeneration &&
+		reflect.DeepEqual(last.Changes, dryRun.Changes) && reflect.DeepEqual(last.Errors, dryRun.Errors) {
+		dryRun.Time = last.Time
+	}
+	instance.Status.DryRun = dryRun
+
+	return r.Client.Status().Patch(context.Background(), instance, client.MergeFrom(orig))
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// rejectingUpdates accepts every create and delete, and rejects every update
+type rejectingUpdates struct {
+	client.Client
+}
+
+func (c rejectingUpdates) Create(context.Context, runtime.Object, ...client.CreateOption) error {
+	return nil
+}
+
+func (c rejectingUpdates) Update(context.Context, runtime.Object, ...client.UpdateOption) error {
+	return fmt.Errorf("rejected")
+}
+
+func (c rejectingUpdates) Delete(context.Context, runtime.Object, ...client.DeleteOption) error {
+	return nil
+}
+
+func TestDryRunClientRecordsChanges(t *testing.T) {
+	t.Parallel()
+
+	object := func(kind, namespace, name string) *uns.Unstructured {
+		obj := &uns.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	dc := &dryRunClient{Client: rejectingUpdates{}}
+	ctx := context.Background()
+	if err := dc.Create(ctx, object("Namespace", "", "cluster-hosted-net-services")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dc.Update(ctx, object("ConfigMap", "cluster-hosted-net-services", "coredns-template")); err == nil {
+		t.Fatalf("expected the update to fail")
+	}
+	if err := dc.Delete(ctx, object("DaemonSet", "cluster-hosted-net-services", "cluster-hosted-mdns")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"create Namespace cluster-hosted-net-services",
+		"delete DaemonSet cluster-hosted-net-services/cluster-hosted-mdns",
+	}
+	if !reflect.DeepEqual(dc.changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, dc.changes)
+	}
+}
+
+// liveObjects serves the objects it holds by name, and accepts every create
+// and update
+type liveObjects struct {
+	client.Client
+	objects map[string]*uns.Unstructured
+}
+
+func (c liveObjects) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	live, ok := c.objects[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	live.DeepCopyInto(obj.(*uns.Unstructured))
+	return nil
+}
+
+func (c liveObjects) Create(context.Context, runtime.Object, ...client.CreateOption) error {
+	return nil
+}
+
+func (c liveObjects) Update(context.Context, runtime.Object, ...client.UpdateOption) error {
+	return nil
+}
+
+func TestDryRunApplyReportsChangedObjects(t *testing.T) {
+	t.Parallel()
+
+	configMap := func(name, data string) *uns.Unstructured {
+		obj := &uns.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("cluster-hosted-net-services")
+		obj.SetName(name)
+		if err := uns.SetNestedField(obj.Object, data, "data", "Corefile"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return obj
+	}
+	// live returns the object as applied with the given data, carrying the
+	// fields set by the API server
+	live := func(name, data string) *uns.Unstructured {
+		obj := configMap(name, data)
+		if _, err := setRenderedHash(obj); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		obj.SetResourceVersion("42")
+		return obj
+	}
+
+	dc := &dryRunClient{Client: liveObjects{objects: map[string]*uns.Unstructured{
+		"unchanged": live("unchanged", "old"),
+		"changed":   live("changed", "old"),
+	}}}
+	r := &ConfigReconciler{Log: log.NullLogger{}}
+	sc := &syncContext{client: dc, dryRun: true}
+	for _, name := range []string{"unchanged", "changed", "missing"} {
+		data := "old"
+		if name != "unchanged" {
+			data = "new"
+		}
+		if err := applyObject(r, context.Background(), sc, configMap(name, data)); err != nil {
+			t.Fatalf("unexpected error applying %s: %v", name, err)
+		}
+	}
+
+	expected := []string{
+		"update ConfigMap cluster-hosted-net-services/changed",
+		"create ConfigMap cluster-hosted-net-services/missing",
+	}
+	if !reflect.DeepEqual(dc.changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, dc.changes)
+	}
+}
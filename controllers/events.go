@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/images"
+)
+
+// Reasons of the Events recorded on the Config
+const (
+	EventReasonComponentEnabled  = "ComponentEnabled"
+	EventReasonComponentDisabled = "ComponentDisabled"
+	EventReasonCreated           = "Created"
+	EventReasonDeleted           = "Deleted"
+	EventReasonImagesChanged     = "ImagesChanged"
+	EventReasonVIPsChanged       = "VIPsChanged"
+	EventReasonSyncFailed        = "SyncFailed"
+	EventReasonSplitBrain        = "SplitBrain"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// eventf records an Event on the Config, when an event recorder is wired.
+func (r *ConfigReconciler) eventf(instance *clusterhostednetservicesopenshiftiov1beta1.Config, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil || instance == nil {
+		return
+	}
+	r.Recorder.Eventf(instance, eventType, reason, messageFmt, args...)
+}
+
+// recordComponentTransitions records an Event for every component the spec
+// enables or disables, compared to the previous component statuses. It is
+// called once the new statuses are patched, so that the reconciles retrying a
+// failed sync don't record the transitions again.
+func (r *ConfigReconciler) recordComponentTransitions(instance *clusterhostednetservicesopenshiftiov1beta1.Config, previous []clusterhostednetservicesopenshiftiov1beta1.ComponentStatus) {
+	wasEnabled := map[string]bool{}
+	for _, status := range previous {
+		wasEnabled[status.Name] = status.Enabled
+	}
+	for _, c := range components {
+		enabled := c.Enabled(&instance.Spec)
+		if enabled == wasEnabled[c.Name()] {
+			continue
+		}
+		if enabled {
+			r.eventf(instance, corev1.EventTypeNormal, EventReasonComponentEnabled, "Enabled %s", c.Name())
+		} else {
+			r.eventf(instance, corev1.EventTypeNormal, EventReasonComponentDisabled, "Disabled %s", c.Name())
+		}
+	}
+}
+
+// changedImages describes the images that differ between two image sets.
+func changedImages(from, to *images.Images) []string {
+	changes := []string{}
+	fromValue, toValue := reflect.ValueOf(*from), reflect.ValueOf(*to)
+	for n := 0; n < fromValue.NumField(); n++ {
+		if fromValue.Field(n).String() == toValue.Field(n).String() {
+			continue
+		}
+		name := strings.Split(fromValue.Type().Field(n).Tag.Get("json"), ",")[0]
+		changes = append(changes, fmt.Sprintf("%s %s -> %s", name, fromValue.Field(n).String(), toValue.Field(n).String()))
+	}
+	return changes
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/images"
+)
+
+func TestRecordComponentTransitions(t *testing.T) {
+	t.Parallel()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &ConfigReconciler{Recorder: recorder}
+
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	instance.Spec.LoadBalancer.ApiLoadbalance = "Enable"
+	instance.Spec.LoadBalancer.DefaultIngressHA = "Enable"
+	instance.Spec.DNS.NodesResolution = "Disable"
+	instance.Spec.DNS.ApiResolution = "Disable"
+	instance.Spec.DNS.AppsResolution = "Disable"
+	previous := []clusterhostednetservicesopenshiftiov1beta1.ComponentStatus{
+		{Name: "keepalived", Enabled: true},
+		{Name: "haproxy", Enabled: false},
+		{Name: "mdns", Enabled: true},
+		{Name: "coredns", Enabled: false},
+	}
+
+	r.recordComponentTransitions(instance, previous)
+	close(recorder.Events)
+
+	events := []string{}
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	expected := []string{
+		"Normal ComponentEnabled Enabled haproxy",
+		"Normal ComponentDisabled Disabled mdns",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected events %v, got %v", expected, events)
+	}
+}
+
+func TestChangedImages(t *testing.T) {
+	t.Parallel()
+
+	from := &images.Images{Coredns: "quay.io/openshift/origin-coredns:4.6", MdnsPublisher: "quay.io/openshift/origin-mdns-publisher:4.6"}
+	to := *from
+	to.Coredns = "quay.io/openshift/origin-coredns:4.7"
+
+	expected := []string{"coredns quay.io/openshift/origin-coredns:4.6 -> quay.io/openshift/origin-coredns:4.7"}
+	if changes := changedImages(from, &to); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+}
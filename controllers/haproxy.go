@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// haproxyComponent load balances the API servers behind the API VIP
+type haproxyComponent struct {
+	daemonSetComponent
+}
+
+func newHaproxyComponent() *haproxyComponent {
+	return &haproxyComponent{daemonSetComponent{
+		name:       "haproxy",
+		daemonSets: []string{"master-cluster-hosted-haproxy"},
+	}}
+}
+
+func (c *haproxyComponent) Enabled(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) bool {
+	return spec.LoadBalancer.ApiLoadbalance == "Enable"
+}
+
+func (c *haproxyComponent) AddRenderData(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, sc *syncContext, data *render.RenderData) {
+	data.Data["HaproxyImage"] = sc.images.HaproxyRouter
+}
+
+func (c *haproxyComponent) ManifestDirs(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) []manifestDir {
+	return []manifestDir{
+		{Name: "haproxy-configmap"},
+		{Name: "haproxy-daemonset"},
+	}
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// keepalivedComponent holds the API and Ingress VIPs. The API VIP is only
+// useful in front of the API load balancer, while the Ingress VIP is managed
+// on both masters and workers.
+type keepalivedComponent struct {
+	daemonSetComponent
+}
+
+func newKeepalivedComponent() *keepalivedComponent {
+	return &keepalivedComponent{daemonSetComponent{
+		name:       "keepalived",
+		daemonSets: []string{"master-cluster-hosted-keepalived", "worker-cluster-hosted-keepalived"},
+	}}
+}
+
+func (c *keepalivedComponent) Enabled(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) bool {
+	return spec.LoadBalancer.ApiLoadbalance == "Enable" || spec.LoadBalancer.DefaultIngressHA == "Enable"
+}
+
+func (c *keepalivedComponent) AddRenderData(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, sc *syncContext, data *render.RenderData) {
+	data.Data["KeepalivedImage"] = sc.images.KeepalivedIpfailover
+	data.Data["EnableAPIVip"] = spec.LoadBalancer.ApiLoadbalance == "Enable"
+	data.Data["EnableAPIVrrpCheck"] = spec.LoadBalancer.ApiVrrpCheck == "Enable"
+	data.Data["EnableIngressVip"] = spec.LoadBalancer.DefaultIngressHA == "Enable"
+	data.Data["EnableUnicast"] = spec.LoadBalancer.Unicast == "Enable"
+}
+
+func (c *keepalivedComponent) ManifestDirs(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) []manifestDir {
+	return []manifestDir{
+		{Name: "keepalived-configmap"},
+		{Name: "keepalived-daemonset"},
+		{Name: "keepalived-worker-daemonset", Disabled: spec.LoadBalancer.DefaultIngressHA != "Enable"},
+	}
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// mdnsComponent publishes the node names over mDNS
+type mdnsComponent struct {
+	daemonSetComponent
+}
+
+func newMDNSComponent() *mdnsComponent {
+	return &mdnsComponent{daemonSetComponent{
+		name:       "mdns",
+		daemonSets: []string{"cluster-hosted-mdns"},
+	}}
+}
+
+func (c *mdnsComponent) Enabled(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) bool {
+	return spec.DNS.NodesResolution == "Enable"
+}
+
+func (c *mdnsComponent) AddRenderData(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, sc *syncContext, data *render.RenderData) {
+	data.Data["MdnsPublisherImage"] = sc.images.MdnsPublisher
+}
+
+func (c *mdnsComponent) ManifestDirs(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) []manifestDir {
+	return []manifestDir{
+		{Name: "mdns-configmap"},
+		{Name: "mdns-daemonset"},
+	}
+}
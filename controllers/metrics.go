@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// DefaultVipFlappingThreshold is the number of changes of the nodes holding a
+// VIP in an hour above which the ClusterHostedVIPFlapping alert fires
+const DefaultVipFlappingThreshold = 5
+
+// Results of a component sync
+const (
+	syncResultSuccess = "success"
+	syncResultError   = "error"
+	// syncResultWaiting is a sync postponed until the dependencies of the
+	// component are rolled out
+	syncResultWaiting = "waiting"
+)
+
+var (
+	componentSyncDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cluster_hosted_net_services_component_sync_duration_seconds",
+			Help:    "Duration of the sync of a component by a reconcile.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"component"},
+	)
+	componentSyncsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cluster_hosted_net_services_component_syncs_total",
+			Help: "Number of syncs of a component by result: success, error or waiting for its dependencies.",
+		},
+		[]string{"component", "result"},
+	)
+	objectsAppliedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cluster_hosted_net_services_objects_applied_total",
+			Help: "Number of handler objects applied.",
+		},
+		[]string{"kind"},
+	)
+	objectsDeletedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cluster_hosted_net_services_objects_deleted_total",
+			Help: "Number of handler objects deleted.",
+		},
+		[]string{"kind"},
+	)
+	skippedAppliesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cluster_hosted_net_services_skipped_applies_total",
+			Help: "Number of handler object applies skipped as the object didn't change since it was applied.",
+		},
+		[]string{"kind"},
+	)
+	componentEnabled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_component_enabled",
+			Help: "Whether the Config enables the component.",
+		},
+		[]string{"component"},
+	)
+	componentDesiredPods = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_component_desired_pods",
+			Help: "Number of handler pods of the component that should be running.",
+		},
+		[]string{"component"},
+	)
+	componentReadyPods = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_component_ready_pods",
+			Help: "Number of handler pods of the component that are ready.",
+		},
+		[]string{"component"},
+	)
+	vipOwner = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_vip_owner",
+			Help: "Set to 1 for the nodes holding the VIP, according to their VIP reports.",
+		},
+		[]string{"vip", "node"},
+	)
+	vipOwnerCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_vip_owners",
+			Help: "Number of nodes holding the VIP, according to their VIP reports.",
+		},
+		[]string{"vip"},
+	)
+	vipOwnerChangesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cluster_hosted_net_services_vip_owner_changes_total",
+			Help: "Number of changes of the nodes holding the VIP.",
+		},
+		[]string{"vip"},
+	)
+	vipFlappingThreshold = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_vip_flapping_threshold",
+			Help: "Number of changes of the nodes holding a VIP in an hour above which it is flapping, as set in the Config spec.",
+		},
+	)
+	haproxyHealthyBackends = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_haproxy_healthy_backends",
+			Help: "Number of healthy API servers in the HAProxy masters backend of the node, according to its backends report.",
+		},
+		[]string{"node"},
+	)
+	dnsRecordErrors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_hosted_net_services_dns_record_errors",
+			Help: "Number of api, api-int and *.apps records the CoreDNS handler of the node doesn't resolve to the VIPs.",
+		},
+		[]string{"node"},
+	)
+	imageLoadFailuresTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cluster_hosted_net_services_image_load_failures_total",
+			Help: "Number of reconciles that failed to load the operand images.",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		componentSyncDuration,
+		componentSyncsTotal,
+		objectsAppliedTotal,
+		objectsDeletedTotal,
+		skippedAppliesTotal,
+		componentEnabled,
+		componentDesiredPods,
+		componentReadyPods,
+		vipOwner,
+		vipOwnerCount,
+		vipOwnerChangesTotal,
+		vipFlappingThreshold,
+		haproxyHealthyBackends,
+		dnsRecordErrors,
+		imageLoadFailuresTotal,
+	)
+}
+
+// setVipOwnerMetrics publishes the nodes holding the API and Ingress VIPs, each
+// given as a comma separated list. VIPs the spec disables have no owner count,
+// not to be alerted on.
+func setVipOwnerMetrics(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, apiVipOwner, ingressVipOwner string) {
+	vipOwner.Reset()
+	for vip, owner := range map[string]string{"api": apiVipOwner, "ingress": ingressVipOwner} {
+		nodes := []string{}
+		if owner != "" {
+			nodes = strings.Split(owner, ",")
+		}
+		for _, node := range nodes {
+			vipOwner.WithLabelValues(vip, node).Set(1)
+		}
+		if !vipEnabled(spec, vip) {
+			vipOwnerCount.DeleteLabelValues(vip)
+			continue
+		}
+		vipOwnerCount.WithLabelValues(vip).Set(float64(len(nodes)))
+	}
+}
+
+func vipEnabled(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, vip string) bool {
+	if vip == "api" {
+		return spec.LoadBalancer.ApiLoadbalance == "Enable"
+	}
+	return spec.LoadBalancer.DefaultIngressHA == "Enable"
+}
+
+// setVipFlappingThreshold publishes the threshold of the VIP flapping alert,
+// whose rule is shipped with the operator manifests and can't be rendered.
+func setVipFlappingThreshold(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+	threshold := spec.Alerts.VipFlappingThreshold
+	if threshold <= 0 {
+		threshold = DefaultVipFlappingThreshold
+	}
+	vipFlappingThreshold.Set(float64(threshold))
+}
+
+// setHealthyBackendsMetrics publishes the healthy HAProxy backends by node.
+// Nodes that don't report them, HAProxy being disabled or not answering, have
+// no value.
+func setHealthyBackendsMetrics(backends map[string]int) {
+	haproxyHealthyBackends.Reset()
+	for node, count := range backends {
+		haproxyHealthyBackends.WithLabelValues(node).Set(float64(count))
+	}
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// gaugeValues returns the values of a registered gauge, keyed by their
+// comma separated label values.
+func gaugeValues(t *testing.T, name string) map[string]float64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := []string{}
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, label.GetValue())
+			}
+			sort.Strings(labels)
+			key := ""
+			for i, label := range labels {
+				if i > 0 {
+					key += ","
+				}
+				key += label
+			}
+			values[key] = metric.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func TestSetVipOwnerMetrics(t *testing.T) {
+	spec := &clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+		LoadBalancer: clusterhostednetservicesopenshiftiov1beta1.HaLoadBalanceConfig{
+			DefaultIngressHA: "Enable",
+			ApiLoadbalance:   "Enable",
+		},
+	}
+	setVipOwnerMetrics(spec, "master-0", "worker-0,worker-1")
+	setVipOwnerMetrics(spec, "master-1", "")
+
+	expectedOwners := map[string]float64{"api,master-1": 1}
+	if owners := gaugeValues(t, "cluster_hosted_net_services_vip_owner"); !reflect.DeepEqual(owners, expectedOwners) {
+		t.Errorf("expected VIP owners %v, got %v", expectedOwners, owners)
+	}
+	expectedCounts := map[string]float64{"api": 1, "ingress": 0}
+	if counts := gaugeValues(t, "cluster_hosted_net_services_vip_owners"); !reflect.DeepEqual(counts, expectedCounts) {
+		t.Errorf("expected VIP owner counts %v, got %v", expectedCounts, counts)
+	}
+
+	// A disabled VIP isn't expected to be held
+	spec.LoadBalancer.DefaultIngressHA = "Disable"
+	setVipOwnerMetrics(spec, "master-1", "")
+
+	expectedCounts = map[string]float64{"api": 1}
+	if counts := gaugeValues(t, "cluster_hosted_net_services_vip_owners"); !reflect.DeepEqual(counts, expectedCounts) {
+		t.Errorf("expected VIP owner counts %v, got %v", expectedCounts, counts)
+	}
+}
+
+func TestSetHealthyBackendsMetrics(t *testing.T) {
+	setHealthyBackendsMetrics(map[string]int{"master-0": 3, "master-1": 1})
+	setHealthyBackendsMetrics(map[string]int{"master-0": 0})
+
+	expected := map[string]float64{"master-0": 0}
+	if backends := gaugeValues(t, "cluster_hosted_net_services_haproxy_healthy_backends"); !reflect.DeepEqual(backends, expected) {
+		t.Errorf("expected healthy backends %v, got %v", expected, backends)
+	}
+}
+
+func TestSetVipFlappingThreshold(t *testing.T) {
+	for _, tc := range []struct {
+		threshold int32
+		expected  float64
+	}{
+		{threshold: 10, expected: 10},
+		{threshold: 0, expected: DefaultVipFlappingThreshold},
+	} {
+		spec := &clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+			Alerts: clusterhostednetservicesopenshiftiov1beta1.AlertsConfig{VipFlappingThreshold: tc.threshold},
+		}
+		setVipFlappingThreshold(spec)
+		expected := map[string]float64{"": tc.expected}
+		if values := gaugeValues(t, "cluster_hosted_net_services_vip_flapping_threshold"); !reflect.DeepEqual(values, expected) {
+			t.Errorf("expected threshold %v, got %v", expected, values)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const (
+	// rolloutResync is how often a reconcile waiting for a rollout to complete
+	// is retried, on top of the DaemonSet watch
+	rolloutResync = 10 * time.Second
+
+	// DefaultDeployTimeout is how long a handler DaemonSet may take to roll
+	// out before the operator reports DeployTimedOut
+	DefaultDeployTimeout = 10 * time.Minute
+)
+
+// rolloutStart is when a DaemonSet generation was first seen not rolled out
+type rolloutStart struct {
+	generation int64
+	time       time.Time
+}
+
+// rolloutStatus is the state of the handler DaemonSets rollouts
+type rolloutStatus struct {
+	// RollingOut lists the DaemonSets not rolled out yet
+	RollingOut []string
+	// TimedOut lists the DaemonSets not rolled out within the deploy timeout
+	TimedOut []string
+	// CrashLooping lists the handler pods in CrashLoopBackOff
+	CrashLooping []string
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// daemonSetRollingOut tells whether some pods of the DaemonSet don't run its
+// latest template yet.
+func daemonSetRollingOut(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration < ds.Generation ||
+		ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled
+}
+
+// daemonSetRolledOut tells whether every pod of the DaemonSet runs its latest
+// template and is available.
+func daemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	return !daemonSetRollingOut(ds) && ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+}
+
+// crashLoopingPods returns the pods having a container in CrashLoopBackOff.
+func crashLoopingPods(pods []corev1.Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		if containerCrashLooping(pod.Status.InitContainerStatuses) || containerCrashLooping(pod.Status.ContainerStatuses) {
+			names = append(names, pod.Name)
+		}
+	}
+	return names
+}
+
+func containerCrashLooping(statuses []corev1.ContainerStatus) bool {
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
+
+// componentRollingOut tells whether a DaemonSet of the component is being
+// rolled out. A rollout exceeding the deploy timeout is reported as TimedOut,
+// and no longer counts as rolling out so that it doesn't hold back the
+// dependents of the component forever.
+func (r *ConfigReconciler) componentRollingOut(c Component, now time.Time) (bool, error) {
+	for _, dsName := range c.DaemonSets() {
+		ds := &appsv1.DaemonSet{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: r.HandlerNamespace}, ds)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if daemonSetRollingOut(ds) && !r.rolloutTimedOut(ds, now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rolloutTimedOut tells whether the rollout of the current DaemonSet
+// generation started more than the deploy timeout ago, as last seen by
+// checkRollouts.
+func (r *ConfigReconciler) rolloutTimedOut(ds *appsv1.DaemonSet, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	start, ok := r.rolloutStarts[ds.Name]
+	return ok && start.generation == ds.Generation && now.Sub(start.time) > r.deployTimeout()
+}
+
+// pendingDependency returns the first dependency of the component that is
+// pending, that is being rolled out, failing or itself waiting for a
+// dependency. A disabled component doesn't wait for its dependencies, so that
+// it can always be deleted.
+func pendingDependency(c Component, spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec, pending map[string]string) string {
+	if !c.Enabled(spec) {
+		return ""
+	}
+	for _, dep := range c.Dependencies() {
+		if pending[dep] != "" {
+			return dep
+		}
+	}
+	return ""
+}
+
+func (r *ConfigReconciler) deployTimeout() time.Duration {
+	if r.DeployTimeout == 0 {
+		return DefaultDeployTimeout
+	}
+	return r.DeployTimeout
+}
+
+// checkRollouts follows the rollout of every handler DaemonSet, looking for
+// crashlooping pods and rollouts exceeding the deploy timeout.
+func (r *ConfigReconciler) checkRollouts(now time.Time) (*rolloutStatus, error) {
+	ctx := context.TODO()
+	status := &rolloutStatus{}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.rolloutStarts == nil {
+		r.rolloutStarts = map[string]rolloutStart{}
+	}
+	timeout := r.deployTimeout()
+
+	for _, c := range components {
+		for _, dsName := range c.DaemonSets() {
+			ds := &appsv1.DaemonSet{}
+			err := r.Client.Get(ctx, types.NamespacedName{Name: dsName, Namespace: r.HandlerNamespace}, ds)
+			if apierrors.IsNotFound(err) {
+				delete(r.rolloutStarts, dsName)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+			if err != nil {
+				return nil, err
+			}
+			pods := &corev1.PodList{}
+			if err := r.Client.List(ctx, pods, client.InNamespace(ds.Namespace),
+				client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil, err
+			}
+			status.CrashLooping = append(status.CrashLooping, crashLoopingPods(pods.Items)...)
+
+			if daemonSetRolledOut(ds) {
+				delete(r.rolloutStarts, dsName)
+				continue
+			}
+			start, ok := r.rolloutStarts[dsName]
+			if !ok || start.generation != ds.Generation {
+				start = rolloutStart{generation: ds.Generation, time: now}
+				r.rolloutStarts[dsName] = start
+			}
+			status.RollingOut = append(status.RollingOut, dsName)
+			if now.Sub(start.time) > timeout {
+				status.TimedOut = append(status.TimedOut, dsName)
+			}
+		}
+	}
+
+	sort.Strings(status.CrashLooping)
+	return status, nil
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDaemonSetRollout(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name               string
+		generation         int64
+		status             appsv1.DaemonSetStatus
+		expectedRollingOut bool
+		expectedRolledOut  bool
+	}{
+		{
+			name:       "rolled out",
+			generation: 2,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     2,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 3,
+				NumberAvailable:        3,
+			},
+			expectedRolledOut: true,
+		},
+		{
+			name:       "new generation not observed",
+			generation: 3,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     2,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 3,
+				NumberAvailable:        3,
+			},
+			expectedRollingOut: true,
+		},
+		{
+			name:       "pods being replaced",
+			generation: 2,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     2,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 1,
+				NumberAvailable:        2,
+			},
+			expectedRollingOut: true,
+		},
+		{
+			name:       "updated pod not available",
+			generation: 2,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     2,
+				DesiredNumberScheduled: 3,
+				UpdatedNumberScheduled: 3,
+				NumberAvailable:        2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: tc.generation},
+				Status:     tc.status,
+			}
+			if rollingOut := daemonSetRollingOut(ds); rollingOut != tc.expectedRollingOut {
+				t.Errorf("expected rolling out %v, got %v", tc.expectedRollingOut, rollingOut)
+			}
+			if rolledOut := daemonSetRolledOut(ds); rolledOut != tc.expectedRolledOut {
+				t.Errorf("expected rolled out %v, got %v", tc.expectedRolledOut, rolledOut)
+			}
+		})
+	}
+}
+
+func TestCrashLoopingPods(t *testing.T) {
+	t.Parallel()
+
+	waiting := func(reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}}
+	}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "running"},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pulling"},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{waiting("ContainerCreating")}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "crashing"},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{waiting("ContainerCreating"), waiting("CrashLoopBackOff")}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "crashing-init"},
+			Status:     corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{waiting("CrashLoopBackOff")}},
+		},
+	}
+
+	expected := []string{"crashing", "crashing-init"}
+	if names := crashLoopingPods(pods); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestRolloutTimedOut(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	r := &ConfigReconciler{
+		DeployTimeout: 10 * time.Minute,
+		rolloutStarts: map[string]rolloutStart{
+			"master-cluster-hosted-keepalived": {generation: 2, time: now.Add(-time.Hour)},
+			"cluster-hosted-mdns":              {generation: 2, time: now.Add(-time.Minute)},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		generation int64
+		expected   bool
+	}{
+		{
+			name:       "master-cluster-hosted-keepalived",
+			generation: 2,
+			expected:   true,
+		},
+		{
+			name:       "cluster-hosted-mdns",
+			generation: 2,
+		},
+		{
+			// A new generation starts a new rollout
+			name:       "master-cluster-hosted-keepalived",
+			generation: 3,
+		},
+		{
+			name:       "cluster-hosted-coredns",
+			generation: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: tc.name, Generation: tc.generation}}
+		if timedOut := r.rolloutTimedOut(ds, now); timedOut != tc.expected {
+			t.Errorf("%s generation %d: expected timed out %v, got %v", tc.name, tc.generation, tc.expected, timedOut)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const (
+	// vipReportComponent is the component label of the Leases renewed by the
+	// keepalived DaemonSets to report the VIPs held by each node
+	vipReportComponent = "cluster-hosted-vip-report"
+
+	// vipReportAnnotation lists the VIPs (api, ingress) held by the node
+	// renewing the Lease
+	vipReportAnnotation = "cluster-hosted-net-services.openshift.io/vips"
+
+	// vrrpStatesAnnotation lists the state (MASTER, BACKUP or FAULT) of the
+	// VRRP instances of the node renewing the Lease, as instance=state pairs
+	vrrpStatesAnnotation = "cluster-hosted-net-services.openshift.io/vrrp-states"
+
+	// backendsReportComponent is the component label of the Leases renewed
+	// by the HAProxy DaemonSet to report the healthy backends of each node
+	backendsReportComponent = "cluster-hosted-haproxy-report"
+
+	// healthyBackendsAnnotation is the number of healthy API servers in the
+	// HAProxy masters backend of the node renewing the Lease, empty while
+	// HAProxy doesn't answer
+	healthyBackendsAnnotation = "cluster-hosted-net-services.openshift.io/healthy-backends"
+
+	// vipReportResync is how often reports are checked for expiry
+	vipReportResync = 15 * time.Second
+
+	// splitBrainGrace is how long the same nodes have to report the MASTER
+	// state of a VRRP instance before it's a split brain. During a failover
+	// the former master keeps reporting it until its next report, or until
+	// its report expires, it is twice the LEASE_DURATION of the VIP reports.
+	splitBrainGrace = 30 * time.Second
+
+	// vipReportGCDelay is how long an expired report is kept before being
+	// deleted. Reports are no longer renewed once their node went away or
+	// keepalived or HAProxy was disabled.
+	vipReportGCDelay = 10 * time.Minute
+)
+
+// VipOwnerReconciler publishes the nodes holding the API and Ingress VIPs, and
+// the states of the VRRP instances, in the Config status. It also exports the
+// healthy HAProxy backends reported by the nodes.
+type VipOwnerReconciler struct {
+	client.Client
+	Log logr.Logger
+	// Recorder records Events on the Config
+	Recorder record.EventRecorder
+	// Namespace is the namespace of the Config singleton
+	Namespace string
+	// HandlerNamespace is the namespace of the VIP reports
+	HandlerNamespace string
+
+	// splitBrainEvents records, by VRRP instance, when the masters of the
+	// split brains recorded in an Event were first seen
+	splitBrainEvents map[string]int64
+}
+
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;delete
+
+func (r *VipOwnerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: ClusterHostedNetServicesConfigCR, Namespace: r.Namespace}, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	leases := &coordinationv1.LeaseList{}
+	if err := r.Client.List(ctx, leases, client.InNamespace(r.HandlerNamespace),
+		client.MatchingLabels{"component": vipReportComponent}); err != nil {
+		return ctrl.Result{}, err
+	}
+	backendsReports := &coordinationv1.LeaseList{}
+	if err := r.Client.List(ctx, backendsReports, client.InNamespace(r.HandlerNamespace),
+		client.MatchingLabels{"component": backendsReportComponent}); err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, reports := range []*coordinationv1.LeaseList{leases, backendsReports} {
+		for i := range reports.Items {
+			lease := &reports.Items[i]
+			if !reportStale(lease, time.Now()) {
+				continue
+			}
+			r.Log.Info("deleting stale report", "lease", lease.Name)
+			if err := r.Client.Delete(ctx, lease); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	apiVipOwner, ingressVipOwner := vipOwners(leases.Items, time.Now())
+	setVipOwnerMetrics(&instance.Spec, apiVipOwner, ingressVipOwner)
+	setVipFlappingThreshold(&instance.Spec)
+	setHealthyBackendsMetrics(healthyBackends(backendsReports.Items, time.Now()))
+
+	now := metav1.Now()
+	orig := instance.DeepCopy()
+	status := &instance.Status
+	if status.APIVipOwner != apiVipOwner {
+		r.Log.Info("API VIP owner changed", "from", status.APIVipOwner, "to", apiVipOwner)
+		status.APIVipOwner = apiVipOwner
+		status.APIVipOwnerTransitionTime = &now
+		vipOwnerChangesTotal.WithLabelValues("api").Inc()
+	}
+	if status.IngressVipOwner != ingressVipOwner {
+		r.Log.Info("Ingress VIP owner changed", "from", status.IngressVipOwner, "to", ingressVipOwner)
+		status.IngressVipOwner = ingressVipOwner
+		status.IngressVipOwnerTransitionTime = &now
+		vipOwnerChangesTotal.WithLabelValues("ingress").Inc()
+	}
+
+	// Several masters for an instance past a failover mean that the nodes
+	// don't see each other's VRRP advertisements, and both hold the VIP
+	lastInstances := status.VRRPInstances
+	status.VRRPInstances = vrrpInstances(leases.Items, time.Now())
+	trackSeveralMasters(lastInstances, status.VRRPInstances, now)
+	splitBrainEvents := map[string]int64{}
+	for _, vrrpInstance := range status.VRRPInstances {
+		if !splitBrain(&vrrpInstance, now.Time) {
+			continue
+		}
+		since := vrrpInstance.SeveralMastersSince.Unix()
+		if r.splitBrainEvents[vrrpInstance.Name] != since {
+			r.Log.Info("VRRP split brain", "instance", vrrpInstance.Name, "masters", vrrpInstance.Masters)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, EventReasonSplitBrain, "VRRP instance %s has several masters: %s",
+					vrrpInstance.Name, strings.Join(vrrpInstance.Masters, ", "))
+			}
+		}
+		splitBrainEvents[vrrpInstance.Name] = since
+	}
+	r.splitBrainEvents = splitBrainEvents
+
+	// The Config reconciler writes the rest of the status, patch only what changed here
+	if !equality.Semantic.DeepEqual(orig.Status, instance.Status) {
+		if err := r.Client.Status().Patch(ctx, instance, client.MergeFrom(orig)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Reports of nodes that went away are only noticed once they expire
+	return ctrl.Result{RequeueAfter: vipReportResync}, nil
+}
+
+// vrrpStatePrecedence orders the VRRP states when the reports of a node
+// disagree, the MASTER state is the one that matters to the VIP owners and to
+// the split brains.
+var vrrpStatePrecedence = map[string]int{"BACKUP": 1, "FAULT": 2, "MASTER": 3}
+
+// nodeVipReport is the merge of the unexpired VIP reports of a node. The
+// master and worker keepalived DaemonSets both report the nodes of a compact
+// cluster.
+type nodeVipReport struct {
+	vips map[string]bool
+	// vrrpStates is the state of every VRRP instance by name
+	vrrpStates map[string]string
+}
+
+// nodeVipReports merges the unexpired VIP reports by node.
+func nodeVipReports(leases []coordinationv1.Lease, now time.Time) map[string]*nodeVipReport {
+	reports := map[string]*nodeVipReport{}
+	for _, lease := range leases {
+		if reportExpired(&lease, now) {
+			continue
+		}
+		node := *lease.Spec.HolderIdentity
+		report, ok := reports[node]
+		if !ok {
+			report = &nodeVipReport{vips: map[string]bool{}, vrrpStates: map[string]string{}}
+			reports[node] = report
+		}
+		for _, vip := range strings.Split(lease.Annotations[vipReportAnnotation], ",") {
+			if vip != "" {
+				report.vips[vip] = true
+			}
+		}
+		if lease.Annotations[vrrpStatesAnnotation] == "" {
+			continue
+		}
+		for _, pair := range strings.Split(lease.Annotations[vrrpStatesAnnotation], ",") {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			if vrrpStatePrecedence[parts[1]] > vrrpStatePrecedence[report.vrrpStates[parts[0]]] {
+				report.vrrpStates[parts[0]] = parts[1]
+			}
+		}
+	}
+	return reports
+}
+
+// vipOwners returns the nodes holding the API and Ingress VIPs according to
+// the unexpired VIP reports.
+func vipOwners(leases []coordinationv1.Lease, now time.Time) (string, string) {
+	apiVipOwners := []string{}
+	ingressVipOwners := []string{}
+
+	for node, report := range nodeVipReports(leases, now) {
+		if report.vips["api"] {
+			apiVipOwners = append(apiVipOwners, node)
+		}
+		if report.vips["ingress"] {
+			ingressVipOwners = append(ingressVipOwners, node)
+		}
+	}
+
+	sort.Strings(apiVipOwners)
+	sort.Strings(ingressVipOwners)
+	return strings.Join(apiVipOwners, ","), strings.Join(ingressVipOwners, ",")
+}
+
+// vrrpInstances returns the nodes by state of every VRRP instance according to
+// the unexpired VIP reports.
+func vrrpInstances(leases []coordinationv1.Lease, now time.Time) []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus {
+	byName := map[string]*clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus{}
+	for node, report := range nodeVipReports(leases, now) {
+		for name, state := range report.vrrpStates {
+			status, ok := byName[name]
+			if !ok {
+				status = &clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus{Name: name}
+				byName[name] = status
+			}
+			switch state {
+			case "MASTER":
+				status.Masters = append(status.Masters, node)
+			case "BACKUP":
+				status.Backups = append(status.Backups, node)
+			case "FAULT":
+				status.Faults = append(status.Faults, node)
+			}
+		}
+	}
+
+	if len(byName) == 0 {
+		return nil
+	}
+	instances := make([]clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus, 0, len(byName))
+	for _, status := range byName {
+		sort.Strings(status.Masters)
+		sort.Strings(status.Backups)
+		sort.Strings(status.Faults)
+		instances = append(instances, *status)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+	return instances
+}
+
+// trackSeveralMasters sets when the masters of the VRRP instances having more
+// than one were first seen, keeping the time of the last statuses listing the
+// same masters.
+func trackSeveralMasters(last, instances []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus, now metav1.Time) {
+	for i := range instances {
+		if len(instances[i].Masters) < 2 {
+			continue
+		}
+		since := now
+		for _, l := range last {
+			if l.Name == instances[i].Name && l.SeveralMastersSince != nil && reflect.DeepEqual(l.Masters, instances[i].Masters) {
+				since = *l.SeveralMastersSince
+			}
+		}
+		instances[i].SeveralMastersSince = &since
+	}
+}
+
+// splitBrain tells whether the same nodes reported the MASTER state of a VRRP
+// instance for longer than splitBrainGrace.
+func splitBrain(instance *clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus, now time.Time) bool {
+	return len(instance.Masters) > 1 && instance.SeveralMastersSince != nil &&
+		now.Sub(instance.SeveralMastersSince.Time) > splitBrainGrace
+}
+
+// splitBrains lists the VRRP instances in split brain.
+func splitBrains(instances []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus, now time.Time) []string {
+	names := []string{}
+	for i := range instances {
+		if splitBrain(&instances[i], now) {
+			names = append(names, fmt.Sprintf("%s (%s)", instances[i].Name, strings.Join(instances[i].Masters, ", ")))
+		}
+	}
+	return names
+}
+
+// pendingSplitBrain returns how long until the first VRRP instance having
+// several masters becomes a split brain, or 0 if none will.
+func pendingSplitBrain(instances []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus, now time.Time) time.Duration {
+	var pending time.Duration
+	for i := range instances {
+		if len(instances[i].Masters) < 2 || instances[i].SeveralMastersSince == nil || splitBrain(&instances[i], now) {
+			continue
+		}
+		// The grace has to be over, not only reached
+		remaining := instances[i].SeveralMastersSince.Add(splitBrainGrace).Sub(now) + time.Second
+		if pending == 0 || remaining < pending {
+			pending = remaining
+		}
+	}
+	return pending
+}
+
+// healthyBackends returns the number of healthy HAProxy backends by node,
+// according to the unexpired reports of the nodes whose HAProxy answered.
+func healthyBackends(leases []coordinationv1.Lease, now time.Time) map[string]int {
+	backends := map[string]int{}
+	for _, lease := range leases {
+		if reportExpired(&lease, now) {
+			continue
+		}
+		count, err := strconv.Atoi(lease.Annotations[healthyBackendsAnnotation])
+		if err != nil {
+			continue
+		}
+		backends[*lease.Spec.HolderIdentity] = count
+	}
+	return backends
+}
+
+// reportExpired tells whether a VIP report is incomplete or wasn't renewed in
+// time.
+func reportExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.HolderIdentity == nil || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expiry)
+}
+
+// reportStale tells whether a VIP report expired more than vipReportGCDelay
+// ago, or was created that long ago when incomplete.
+func reportStale(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return now.After(lease.CreationTimestamp.Add(vipReportGCDelay))
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expiry.Add(vipReportGCDelay))
+}
+
+func isReport(labels map[string]string) bool {
+	return labels["component"] == vipReportComponent || labels["component"] == backendsReportComponent
+}
+
+func (r *VipOwnerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Lease renewals are only interesting when the reported VIPs, VRRP
+	// states or healthy backends change
+	reportChanged := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isReport(e.Meta.GetLabels())
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isReport(e.Meta.GetLabels())
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isReport(e.MetaNew.GetLabels()) &&
+				(e.MetaOld.GetAnnotations()[vipReportAnnotation] != e.MetaNew.GetAnnotations()[vipReportAnnotation] ||
+					e.MetaOld.GetAnnotations()[vrrpStatesAnnotation] != e.MetaNew.GetAnnotations()[vrrpStatesAnnotation] ||
+					e.MetaOld.GetAnnotations()[healthyBackendsAnnotation] != e.MetaNew.GetAnnotations()[healthyBackendsAnnotation])
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("vipowner").
+		For(&coordinationv1.Lease{}, builder.WithPredicates(reportChanged)).
+		Complete(r)
+}
@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+func vipReport(node, vips string, renewTime time.Time) coordinationv1.Lease {
+	duration := int32(15)
+	renew := metav1.NewMicroTime(renewTime)
+	return coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vips-master-" + node,
+			Labels:      map[string]string{"component": vipReportComponent},
+			Annotations: map[string]string{vipReportAnnotation: vips},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &node,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renew,
+		},
+	}
+}
+
+func TestVipOwners(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name            string
+		leases          []coordinationv1.Lease
+		expectedAPI     string
+		expectedIngress string
+	}{
+		{
+			name: "single owners",
+			leases: []coordinationv1.Lease{
+				vipReport("master-0", "api", now),
+				vipReport("master-1", "", now),
+				vipReport("worker-0", "ingress", now),
+			},
+			expectedAPI:     "master-0",
+			expectedIngress: "worker-0",
+		},
+		{
+			name: "both VIPs on one node",
+			leases: []coordinationv1.Lease{
+				vipReport("master-0", "api,ingress", now),
+			},
+			expectedAPI:     "master-0",
+			expectedIngress: "master-0",
+		},
+		{
+			name: "expired report",
+			leases: []coordinationv1.Lease{
+				vipReport("master-0", "api", now.Add(-time.Minute)),
+				vipReport("master-1", "ingress", now),
+			},
+			expectedAPI:     "",
+			expectedIngress: "master-1",
+		},
+		{
+			name: "several nodes claiming a VIP",
+			leases: []coordinationv1.Lease{
+				vipReport("master-2", "api", now),
+				vipReport("master-0", "api", now),
+			},
+			expectedAPI: "master-0,master-2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiVipOwner, ingressVipOwner := vipOwners(tc.leases, now)
+			if apiVipOwner != tc.expectedAPI || ingressVipOwner != tc.expectedIngress {
+				t.Errorf("expected (%q, %q), got (%q, %q)", tc.expectedAPI, tc.expectedIngress, apiVipOwner, ingressVipOwner)
+			}
+		})
+	}
+}
+
+func vrrpReport(node, states string, renewTime time.Time) coordinationv1.Lease {
+	lease := vipReport(node, "", renewTime)
+	lease.Annotations[vrrpStatesAnnotation] = states
+	return lease
+}
+
+func TestVrrpInstances(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	leases := []coordinationv1.Lease{
+		vrrpReport("master-0", "ostest_API=MASTER,ostest_INGRESS=BACKUP", now),
+		vrrpReport("master-1", "ostest_API=MASTER,ostest_INGRESS=FAULT", now),
+		vrrpReport("master-2", "ostest_API=BACKUP,ostest_INGRESS=MASTER", now),
+		vrrpReport("worker-0", "ostest_INGRESS=MASTER", now.Add(-time.Minute)),
+		vipReport("worker-1", "", now),
+	}
+
+	expected := []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus{
+		{Name: "ostest_API", Masters: []string{"master-0", "master-1"}, Backups: []string{"master-2"}},
+		{Name: "ostest_INGRESS", Masters: []string{"master-2"}, Backups: []string{"master-0"}, Faults: []string{"master-1"}},
+	}
+	instances := vrrpInstances(leases, now)
+	if !reflect.DeepEqual(instances, expected) {
+		t.Errorf("expected %v, got %v", expected, instances)
+	}
+
+	if instances := vrrpInstances([]coordinationv1.Lease{vipReport("master-0", "api", now)}, now); instances != nil {
+		t.Errorf("expected no VRRP instance, got %v", instances)
+	}
+}
+
+func TestCompactNodeVipReports(t *testing.T) {
+	t.Parallel()
+
+	// The master and worker keepalived DaemonSets both report master-0
+	now := time.Now()
+	workerReport := vrrpReport("master-0", "ostest_INGRESS=MASTER", now)
+	workerReport.Name = "vips-worker-master-0"
+	workerReport.Annotations[vipReportAnnotation] = "ingress"
+	masterReport := vrrpReport("master-0", "ostest_API=MASTER,ostest_INGRESS=BACKUP", now)
+	masterReport.Annotations[vipReportAnnotation] = "api"
+	leases := []coordinationv1.Lease{
+		masterReport,
+		workerReport,
+		vrrpReport("master-1", "ostest_API=BACKUP,ostest_INGRESS=BACKUP", now),
+	}
+
+	apiVipOwner, ingressVipOwner := vipOwners(leases, now)
+	if apiVipOwner != "master-0" || ingressVipOwner != "master-0" {
+		t.Errorf("expected (%q, %q), got (%q, %q)", "master-0", "master-0", apiVipOwner, ingressVipOwner)
+	}
+
+	expected := []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus{
+		{Name: "ostest_API", Masters: []string{"master-0"}, Backups: []string{"master-1"}},
+		{Name: "ostest_INGRESS", Masters: []string{"master-0"}, Backups: []string{"master-1"}},
+	}
+	// The reports are merged whatever their order
+	for _, leases := range [][]coordinationv1.Lease{leases, {leases[2], leases[1], leases[0]}} {
+		if instances := vrrpInstances(leases, now); !reflect.DeepEqual(instances, expected) {
+			t.Errorf("expected %v, got %v", expected, instances)
+		}
+	}
+}
+
+func TestSplitBrains(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	masters := func(nodes ...string) []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus {
+		return []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus{{Name: "ostest_API", Masters: nodes}}
+	}
+
+	// Every step is 10s after the previous one
+	steps := []struct {
+		name       string
+		instances  []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus
+		splitBrain bool
+	}{
+		{name: "single master", instances: masters("master-0")},
+		{name: "failover to master-1", instances: masters("master-0", "master-1")},
+		{name: "failover done", instances: masters("master-1")},
+		{name: "two masters", instances: masters("master-1", "master-2")},
+		{name: "two masters for 10s", instances: masters("master-1", "master-2")},
+		{name: "other two masters", instances: masters("master-0", "master-1")},
+		{name: "other two masters for 10s", instances: masters("master-0", "master-1")},
+		{name: "other two masters for 20s", instances: masters("master-0", "master-1")},
+		{name: "other two masters for 30s", instances: masters("master-0", "master-1")},
+		{name: "other two masters for 40s", instances: masters("master-0", "master-1"), splitBrain: true},
+		{name: "split brain resolved", instances: masters("master-1")},
+	}
+
+	var last []clusterhostednetservicesopenshiftiov1beta1.VRRPInstanceStatus
+	for i, step := range steps {
+		now := start.Add(time.Duration(i) * 10 * time.Second)
+		trackSeveralMasters(last, step.instances, metav1.NewTime(now))
+		last = step.instances
+
+		split := splitBrains(step.instances, now)
+		if step.splitBrain && !reflect.DeepEqual(split, []string{"ostest_API (master-0, master-1)"}) {
+			t.Errorf("%s: expected a split brain of ostest_API, got %v", step.name, split)
+		}
+		if !step.splitBrain && len(split) > 0 {
+			t.Errorf("%s: unexpected split brains %v", step.name, split)
+		}
+	}
+
+	pending := masters("master-0", "master-1")
+	trackSeveralMasters(nil, pending, metav1.NewTime(start))
+	if wait := pendingSplitBrain(pending, start.Add(10*time.Second)); wait != splitBrainGrace-9*time.Second {
+		t.Errorf("expected the split brain in %s, got %s", splitBrainGrace-9*time.Second, wait)
+	}
+	if wait := pendingSplitBrain(pending, start.Add(time.Minute)); wait != 0 {
+		t.Errorf("expected no pending split brain, got %s", wait)
+	}
+}
+
+func TestReportStale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	incomplete := vipReport("master-1", "", now)
+	incomplete.Spec.RenewTime = nil
+	incomplete.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+
+	testCases := []struct {
+		name     string
+		lease    coordinationv1.Lease
+		expected bool
+	}{
+		{
+			name:  "renewed",
+			lease: vipReport("master-0", "api", now),
+		},
+		{
+			name:  "recently expired",
+			lease: vipReport("master-0", "api", now.Add(-time.Minute)),
+		},
+		{
+			name:     "expired long ago",
+			lease:    vipReport("master-0", "api", now.Add(-time.Hour)),
+			expected: true,
+		},
+		{
+			name:     "incomplete",
+			lease:    incomplete,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if stale := reportStale(&tc.lease, now); stale != tc.expected {
+				t.Errorf("expected stale %t, got %t", tc.expected, stale)
+			}
+		})
+	}
+}
+
+func backendsReport(node, backends string, renewTime time.Time) coordinationv1.Lease {
+	lease := vipReport(node, "", renewTime)
+	lease.Name = "haproxy-" + node
+	lease.Labels["component"] = backendsReportComponent
+	lease.Annotations = map[string]string{healthyBackendsAnnotation: backends}
+	return lease
+}
+
+func TestHealthyBackends(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	leases := []coordinationv1.Lease{
+		backendsReport("master-0", "3", now),
+		backendsReport("master-1", "0", now),
+		backendsReport("master-2", "", now),
+		backendsReport("master-3", "2", now.Add(-time.Minute)),
+	}
+
+	expected := map[string]int{"master-0": 3, "master-1": 0}
+	if backends := healthyBackends(leases, now); !reflect.DeepEqual(backends, expected) {
+		t.Errorf("expected %v, got %v", expected, backends)
+	}
+}
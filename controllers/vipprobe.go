@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	osclientset "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+const (
+	// DefaultAPIProbePort is the port of the API server readyz endpoint
+	DefaultAPIProbePort = 6443
+	// DefaultIngressProbePort is the port of the router health endpoint
+	DefaultIngressProbePort = 1936
+	// DefaultProbeTimeout is how long a VIP probe may take
+	DefaultProbeTimeout = 5 * time.Second
+	// DefaultProbePeriod is the time between the probes of the VIPs
+	DefaultProbePeriod = 30 * time.Second
+
+	apiProbePath     = "/readyz"
+	ingressProbePath = "/healthz/ready"
+
+	// vipProbeThreshold is the number of consecutive probes that have to
+	// disagree with the reachability of a VIP for it to change, so that a
+	// single lost probe doesn't degrade the operator
+	vipProbeThreshold = 3
+)
+
+// VipProber periodically checks that the API and Ingress VIPs are reachable,
+// and that the CoreDNS handlers resolve the names to them, and records the
+// results in the Config status. Changing the status triggers a reconcile of
+// the Config, which reports the unreachable VIPs in the ClusterOperator
+// Degraded condition.
+type VipProber struct {
+	client.Client
+	Log      logr.Logger
+	OSClient osclientset.Interface
+	// Namespace is the namespace of the Config singleton
+	Namespace string
+	// HandlerNamespace is the namespace of the CoreDNS handlers
+	HandlerNamespace string
+
+	// disagreements counts, by VIP address, the consecutive probes that
+	// disagree with the reachability in the status
+	disagreements map[string]int
+}
+
+// probesConfig returns the probes configuration of the spec, defaulting the
+// unset fields.
+func probesConfig(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) clusterhostednetservicesopenshiftiov1beta1.ProbesConfig {
+	probes := *spec.Probes.DeepCopy()
+	if probes.APIPort == 0 {
+		probes.APIPort = DefaultAPIProbePort
+	}
+	if probes.IngressPort == 0 {
+		probes.IngressPort = DefaultIngressProbePort
+	}
+	if probes.Timeout == nil || probes.Timeout.Duration <= 0 {
+		probes.Timeout = &metav1.Duration{Duration: DefaultProbeTimeout}
+	}
+	if probes.Period == nil || probes.Period.Duration <= 0 {
+		probes.Period = &metav1.Duration{Duration: DefaultProbePeriod}
+	}
+	return probes
+}
+
+// Start probes the VIPs until the stop channel is closed.
+func (p *VipProber) Start(stop <-chan struct{}) error {
+	for {
+		period, err := p.probeVips(context.Background())
+		if err != nil {
+			p.Log.Error(err, "failed probing the VIPs")
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(period):
+		}
+	}
+}
+
+// probeVips probes every enabled VIP and the CoreDNS handlers once, and
+// returns the time to wait before probing them again.
+func (p *VipProber) probeVips(ctx context.Context) (time.Duration, error) {
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: ClusterHostedNetServicesConfigCR, Namespace: p.Namespace}, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return DefaultProbePeriod, nil
+		}
+		return DefaultProbePeriod, err
+	}
+	probes := probesConfig(&instance.Spec)
+
+	infra, err := p.OSClient.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return probes.Period.Duration, errors.Wrap(err, "unable to read the Infrastructure")
+	}
+	apiVip, ingressVip, err := platformVips(infra)
+	if err != nil {
+		return probes.Period.Duration, err
+	}
+	apiVips, ingressVips, err := vipsDetails(instance, apiVip, ingressVip)
+	if err != nil {
+		return probes.Period.Duration, err
+	}
+
+	httpClient := newProbeClient(probes.Timeout.Duration)
+	results := []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus{}
+	for vip, addresses := range map[string][]string{"api": apiVips, "ingress": ingressVips} {
+		if !vipEnabled(&instance.Spec, vip) {
+			continue
+		}
+		for _, address := range addresses {
+			result := clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus{VIP: vip, Address: address, Reachable: true}
+			if err := probeVip(httpClient, probeURL(vip, address, &probes)); err != nil {
+				p.Log.Info("VIP probe failed", "vip", vip, "address", address, "error", err.Error())
+				result.Reachable = false
+			}
+			results = append(results, result)
+		}
+	}
+
+	// A failing DNS check doesn't prevent the probe results from being
+	// recorded
+	var nodeErrors []clusterhostednetservicesopenshiftiov1beta1.NodeDNSRecordErrors
+	records, dnsErr := expectedDNSRecords(&instance.Spec, infra, apiVips, ingressVips)
+	if dnsErr == nil {
+		nodeErrors, dnsErr = p.checkDNSRecords(ctx, records, probes.Timeout.Duration)
+	}
+	if dnsErr != nil {
+		dnsErr = errors.Wrap(dnsErr, "failed checking the DNS records")
+	}
+
+	orig := instance.DeepCopy()
+	instance.Status.VipProbes, p.disagreements = updateVipProbes(instance.Status.VipProbes, results, p.disagreements, metav1.Now())
+	updateDNSCheck(&instance.Status, nodeErrors, dnsErr)
+	if equality.Semantic.DeepEqual(orig.Status, instance.Status) {
+		return probes.Period.Duration, dnsErr
+	}
+	for _, result := range instance.Status.VipProbes {
+		if !result.Reachable {
+			p.Log.Info("VIP unreachable", "vip", result.VIP, "address", result.Address)
+		}
+	}
+	for _, node := range instance.Status.DNSRecordErrors {
+		p.Log.Info("wrong DNS records", "node", node.Node, "errors", node.Errors)
+	}
+	if err := p.Client.Status().Patch(ctx, instance, client.MergeFrom(orig)); err != nil {
+		return probes.Period.Duration, err
+	}
+	return probes.Period.Duration, dnsErr
+}
+
+// newProbeClient returns the HTTP client probing the VIPs. The API serving
+// certificate isn't necessarily valid for the VIP addresses, and only the
+// reachability of the VIPs matters, so it isn't verified.
+func newProbeClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+	}
+}
+
+// probeURL returns the URL of the health endpoint probed on a VIP address.
+func probeURL(vip, address string, probes *clusterhostednetservicesopenshiftiov1beta1.ProbesConfig) string {
+	if vip == "api" {
+		return "https://" + net.JoinHostPort(address, strconv.Itoa(int(probes.APIPort))) + apiProbePath
+	}
+	return "http://" + net.JoinHostPort(address, strconv.Itoa(int(probes.IngressPort))) + ingressProbePath
+}
+
+// probeVip checks that the health endpoint answers with a success.
+func probeVip(httpClient *http.Client, url string) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// updateVipProbes returns the statuses of the probed VIPs, sorted, along with
+// the updated counts of the consecutive probes disagreeing with them. The
+// reachability of a VIP, and its transition time and message, only change once
+// vipProbeThreshold consecutive probes disagree with it. VIPs probed for the
+// first time are deemed reachable until then.
+func updateVipProbes(last, results []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus, disagreements map[string]int,
+	now metav1.Time) ([]clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus, map[string]int) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].VIP != results[j].VIP {
+			return results[i].VIP < results[j].VIP
+		}
+		return results[i].Address < results[j].Address
+	})
+
+	statuses := make([]clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus, 0, len(results))
+	counts := map[string]int{}
+	for _, result := range results {
+		key := result.VIP + "/" + result.Address
+		status := clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus{
+			VIP: result.VIP, Address: result.Address, Reachable: true, LastTransitionTime: now,
+		}
+		for _, l := range last {
+			if l.VIP == result.VIP && l.Address == result.Address {
+				status = l
+			}
+		}
+
+		if result.Reachable != status.Reachable {
+			counts[key] = disagreements[key] + 1
+		}
+		if counts[key] >= vipProbeThreshold {
+			status.Reachable = result.Reachable
+			status.LastTransitionTime = now
+			status.Message = ""
+			if !status.Reachable {
+				status.Message = fmt.Sprintf("the last %d probes failed", vipProbeThreshold)
+			}
+			delete(counts, key)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, counts
+}
+
+// updateDNSCheck records the outcome of a check of the DNS records. When the
+// check failed, the errors found by the last successful one are kept.
+func updateDNSCheck(status *clusterhostednetservicesopenshiftiov1beta1.ConfigStatus,
+	nodeErrors []clusterhostednetservicesopenshiftiov1beta1.NodeDNSRecordErrors, err error) {
+	if err != nil {
+		status.DNSCheckError = err.Error()
+		return
+	}
+	status.DNSCheckError = ""
+	status.DNSRecordErrors = nodeErrors
+}
+
+// unreachableVips lists the VIP addresses deemed unreachable.
+func unreachableVips(statuses []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus) []string {
+	unreachable := []string{}
+	for _, status := range statuses {
+		if !status.Reachable {
+			unreachable = append(unreachable, fmt.Sprintf("%s %s", status.VIP, status.Address))
+		}
+	}
+	return unreachable
+}
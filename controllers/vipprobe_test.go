@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// serverAddress returns the address and port of a test server.
+func serverAddress(t *testing.T, server *httptest.Server) (string, int32) {
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return host, int32(p)
+}
+
+func TestProbeVip(t *testing.T) {
+	t.Parallel()
+
+	healthy := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != path {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte("ok"))
+		}
+	}
+	unhealthy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	})
+
+	testCases := []struct {
+		name          string
+		vip           string
+		tls           bool
+		handler       http.Handler
+		stopped       bool
+		expectedError bool
+	}{
+		{
+			name:    "API readyz",
+			vip:     "api",
+			tls:     true,
+			handler: healthy(apiProbePath),
+		},
+		{
+			name:          "API not ready",
+			vip:           "api",
+			tls:           true,
+			handler:       unhealthy,
+			expectedError: true,
+		},
+		{
+			name:          "API timeout",
+			vip:           "api",
+			tls:           true,
+			handler:       slow,
+			expectedError: true,
+		},
+		{
+			name:    "ingress healthz",
+			vip:     "ingress",
+			handler: healthy(ingressProbePath),
+		},
+		{
+			name:          "ingress not listening",
+			vip:           "ingress",
+			handler:       healthy(ingressProbePath),
+			stopped:       true,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var server *httptest.Server
+			if tc.tls {
+				server = httptest.NewTLSServer(tc.handler)
+			} else {
+				server = httptest.NewServer(tc.handler)
+			}
+			defer server.Close()
+			address, port := serverAddress(t, server)
+			if tc.stopped {
+				server.Close()
+			}
+
+			probes := probesConfig(&clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{
+				Probes: clusterhostednetservicesopenshiftiov1beta1.ProbesConfig{
+					APIPort:     port,
+					IngressPort: port,
+					Timeout:     &metav1.Duration{Duration: 100 * time.Millisecond},
+				},
+			})
+			err := probeVip(newProbeClient(probes.Timeout.Duration), probeURL(tc.vip, address, &probes))
+			if tc.expectedError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestProbesConfig(t *testing.T) {
+	t.Parallel()
+
+	probes := probesConfig(&clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{})
+	if probes.APIPort != DefaultAPIProbePort || probes.IngressPort != DefaultIngressProbePort {
+		t.Errorf("expected default ports, got %d and %d", probes.APIPort, probes.IngressPort)
+	}
+	if probes.Timeout.Duration != DefaultProbeTimeout || probes.Period.Duration != DefaultProbePeriod {
+		t.Errorf("expected default timeout and period, got %s and %s", probes.Timeout.Duration, probes.Period.Duration)
+	}
+	if url := probeURL("api", "fd00::5", &probes); url != "https://[fd00::5]:6443/readyz" {
+		t.Errorf("expected API probe URL https://[fd00::5]:6443/readyz, got %s", url)
+	}
+	if url := probeURL("ingress", "192.168.111.4", &probes); url != "http://192.168.111.4:1936/healthz/ready" {
+		t.Errorf("expected Ingress probe URL http://192.168.111.4:1936/healthz/ready, got %s", url)
+	}
+}
+
+func TestUpdateVipProbes(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	unreachable := fmt.Sprintf("the last %d probes failed", vipProbeThreshold)
+
+	// The Ingress VIP is probed every minute, the API VIP always answers
+	steps := []struct {
+		name       string
+		reachable  bool
+		expected   bool
+		message    string
+		transition int
+	}{
+		{name: "first probe failed", reachable: false, expected: true},
+		{name: "success in between", reachable: true, expected: true},
+		{name: "1st consecutive failure", reachable: false, expected: true},
+		{name: "2nd consecutive failure", reachable: false, expected: true},
+		{name: "3rd consecutive failure", reachable: false, expected: false, message: unreachable, transition: 4},
+		{name: "single success", reachable: true, expected: false, message: unreachable, transition: 4},
+		{name: "failure again", reachable: false, expected: false, message: unreachable, transition: 4},
+		{name: "1st consecutive success", reachable: true, expected: false, message: unreachable, transition: 4},
+		{name: "2nd consecutive success", reachable: true, expected: false, message: unreachable, transition: 4},
+		{name: "3rd consecutive success", reachable: true, expected: true, transition: 9},
+	}
+
+	var statuses []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus
+	var disagreements map[string]int
+	for i, step := range steps {
+		results := []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus{
+			{VIP: "ingress", Address: "192.168.111.4", Reachable: step.reachable},
+			{VIP: "api", Address: "192.168.111.5", Reachable: true},
+		}
+		now := metav1.NewTime(start.Add(time.Duration(i) * time.Minute))
+		statuses, disagreements = updateVipProbes(statuses, results, disagreements, now)
+
+		expected := []clusterhostednetservicesopenshiftiov1beta1.VipProbeStatus{
+			{VIP: "api", Address: "192.168.111.5", Reachable: true, LastTransitionTime: metav1.NewTime(start)},
+			{VIP: "ingress", Address: "192.168.111.4", Reachable: step.expected, Message: step.message,
+				LastTransitionTime: metav1.NewTime(start.Add(time.Duration(step.transition) * time.Minute))},
+		}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Fatalf("%s: expected %v, got %v", step.name, expected, statuses)
+		}
+		if unreachable := unreachableVips(statuses); (len(unreachable) == 0) != step.expected {
+			t.Errorf("%s: unexpected unreachable VIPs %v", step.name, unreachable)
+		}
+	}
+}
+
+func TestUpdateDNSCheck(t *testing.T) {
+	t.Parallel()
+
+	nodeErrors := []clusterhostednetservicesopenshiftiov1beta1.NodeDNSRecordErrors{
+		{Node: "master-0", Errors: []string{"api.ostest.test.metalkube.org: expected 192.168.111.5, got 192.168.111.6"}},
+	}
+	status := &clusterhostednetservicesopenshiftiov1beta1.ConfigStatus{}
+
+	updateDNSCheck(status, nodeErrors, nil)
+	if !reflect.DeepEqual(status.DNSRecordErrors, nodeErrors) || status.DNSCheckError != "" {
+		t.Errorf("expected the DNS record errors recorded, got %v and %q", status.DNSRecordErrors, status.DNSCheckError)
+	}
+
+	// The errors of the last successful check are kept
+	updateDNSCheck(status, nil, fmt.Errorf("failed checking the DNS records: connection refused"))
+	if !reflect.DeepEqual(status.DNSRecordErrors, nodeErrors) || status.DNSCheckError != "failed checking the DNS records: connection refused" {
+		t.Errorf("expected the DNS check error recorded, got %v and %q", status.DNSRecordErrors, status.DNSCheckError)
+	}
+
+	updateDNSCheck(status, nil, nil)
+	if status.DNSRecordErrors != nil || status.DNSCheckError != "" {
+		t.Errorf("expected the DNS check errors cleared, got %v and %q", status.DNSRecordErrors, status.DNSCheckError)
+	}
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	osconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+)
+
+// platformVips returns the API and Ingress VIPs published by the installer in
+// the PlatformStatus member matching the cluster platform.
+func platformVips(infra *osconfigv1.Infrastructure) (string, string, error) {
+	platform := infra.Status.Platform
+	platformStatus := infra.Status.PlatformStatus
+	if platformStatus == nil {
+		return "", "", fmt.Errorf("platform status is missing for platform %q", platform)
+	}
+	if platformStatus.Type != "" {
+		platform = platformStatus.Type
+	}
+
+	var apiVip, ingressVip string
+	switch platform {
+	case osconfigv1.BareMetalPlatformType:
+		if platformStatus.BareMetal == nil {
+			return "", "", fmt.Errorf("BareMetal platform status is missing")
+		}
+		apiVip = platformStatus.BareMetal.APIServerInternalIP
+		ingressVip = platformStatus.BareMetal.IngressIP
+	case osconfigv1.OpenStackPlatformType:
+		if platformStatus.OpenStack == nil {
+			return "", "", fmt.Errorf("OpenStack platform status is missing")
+		}
+		apiVip = platformStatus.OpenStack.APIServerInternalIP
+		ingressVip = platformStatus.OpenStack.IngressIP
+	case osconfigv1.VSpherePlatformType:
+		if platformStatus.VSphere == nil {
+			return "", "", fmt.Errorf("VSphere platform status is missing")
+		}
+		apiVip = platformStatus.VSphere.APIServerInternalIP
+		ingressVip = platformStatus.VSphere.IngressIP
+	case osconfigv1.OvirtPlatformType:
+		if platformStatus.Ovirt == nil {
+			return "", "", fmt.Errorf("oVirt platform status is missing")
+		}
+		apiVip = platformStatus.Ovirt.APIServerInternalIP
+		ingressVip = platformStatus.Ovirt.IngressIP
+	default:
+		return "", "", fmt.Errorf("platform %q does not provide VIPs", platform)
+	}
+
+	if apiVip == "" || ingressVip == "" {
+		return "", "", fmt.Errorf("%s platform status is missing VIPs (api: %q, ingress: %q)", platform, apiVip, ingressVip)
+	}
+	return apiVip, ingressVip, nil
+}
+
+// renderVip is a VIP as consumed by the handler templates.
+type renderVip struct {
+	Address string
+	// Family is either IPv4 or IPv6
+	Family string
+}
+
+// vipRecord is a CoreDNS template record resolving a name to a VIP. A record
+// without an Address answers with no data, so queries for an address family
+// that has no VIP don't fall through to the upstream resolvers.
+type vipRecord struct {
+	// Type is either A or AAAA
+	Type    string
+	Address string
+}
+
+func ipFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// dualStackVips returns the VIP read from the Infrastructure status followed
+// by the additional VIPs set in the Config spec. At most one VIP is allowed per
+// address family.
+func dualStackVips(primary string, additional []string) ([]string, error) {
+	vips := []string{}
+	families := map[string]bool{}
+	for _, vip := range append([]string{primary}, additional...) {
+		ip := net.ParseIP(vip)
+		if ip == nil {
+			return nil, fmt.Errorf("%q is not a valid IP address", vip)
+		}
+		family := ipFamily(ip)
+		if families[family] {
+			return nil, fmt.Errorf("more than one %s VIP set (%s)", family, strings.Join(append(vips, vip), ", "))
+		}
+		families[family] = true
+		vips = append(vips, vip)
+	}
+	return vips, nil
+}
+
+func renderVips(vips []string) []renderVip {
+	out := []renderVip{}
+	for _, vip := range vips {
+		out = append(out, renderVip{Address: vip, Family: ipFamily(net.ParseIP(vip))})
+	}
+	return out
+}
+
+func vipRecords(vips []string) []vipRecord {
+	records := []vipRecord{{Type: "A"}, {Type: "AAAA"}}
+	for _, vip := range vips {
+		if ipFamily(net.ParseIP(vip)) == "IPv4" {
+			records[0].Address = vip
+		} else {
+			records[1].Address = vip
+		}
+	}
+	return records
+}
+
+// addVipsRenderData sets the VIP fields used by the handler templates. The
+// single VIP fields hold the primary VIPs, which are the ones passed to the
+// baremetal-runtimecfg tools.
+func addVipsRenderData(data *render.RenderData, apiVips, ingressVips []string) {
+	data.Data["OnPremPlatformAPIServerInternalIP"] = apiVips[0]
+	data.Data["OnPremPlatformIngressIP"] = ingressVips[0]
+	data.Data["APIVips"] = renderVips(apiVips)
+	data.Data["IngressVips"] = renderVips(ingressVips)
+	data.Data["APIVipRecords"] = vipRecords(apiVips)
+	data.Data["IngressVipRecords"] = vipRecords(ingressVips)
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	osconfigv1 "github.com/openshift/api/config/v1"
+)
+
+func TestPlatformVips(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		status          osconfigv1.InfrastructureStatus
+		expectedAPI     string
+		expectedIngress string
+		expectedErr     bool
+	}{
+		{
+			name: "baremetal",
+			status: osconfigv1.InfrastructureStatus{
+				Platform: osconfigv1.BareMetalPlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{
+					Type:      osconfigv1.BareMetalPlatformType,
+					BareMetal: &osconfigv1.BareMetalPlatformStatus{APIServerInternalIP: "192.168.111.5", IngressIP: "192.168.111.4"},
+				},
+			},
+			expectedAPI:     "192.168.111.5",
+			expectedIngress: "192.168.111.4",
+		},
+		{
+			name: "openstack",
+			status: osconfigv1.InfrastructureStatus{
+				Platform: osconfigv1.OpenStackPlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{
+					Type:      osconfigv1.OpenStackPlatformType,
+					OpenStack: &osconfigv1.OpenStackPlatformStatus{APIServerInternalIP: "10.0.0.5", IngressIP: "10.0.0.7"},
+				},
+			},
+			expectedAPI:     "10.0.0.5",
+			expectedIngress: "10.0.0.7",
+		},
+		{
+			name: "vsphere",
+			status: osconfigv1.InfrastructureStatus{
+				Platform: osconfigv1.VSpherePlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{
+					Type:    osconfigv1.VSpherePlatformType,
+					VSphere: &osconfigv1.VSpherePlatformStatus{APIServerInternalIP: "10.0.1.5", IngressIP: "10.0.1.7"},
+				},
+			},
+			expectedAPI:     "10.0.1.5",
+			expectedIngress: "10.0.1.7",
+		},
+		{
+			name: "ovirt",
+			status: osconfigv1.InfrastructureStatus{
+				Platform: osconfigv1.OvirtPlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{
+					Type:  osconfigv1.OvirtPlatformType,
+					Ovirt: &osconfigv1.OvirtPlatformStatus{APIServerInternalIP: "10.0.2.5", IngressIP: "10.0.2.7"},
+				},
+			},
+			expectedAPI:     "10.0.2.5",
+			expectedIngress: "10.0.2.7",
+		},
+		{
+			name: "missing platform status",
+			status: osconfigv1.InfrastructureStatus{
+				Platform: osconfigv1.VSpherePlatformType,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "missing platform member",
+			status: osconfigv1.InfrastructureStatus{
+				Platform:       osconfigv1.OpenStackPlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{Type: osconfigv1.OpenStackPlatformType},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "missing ingress VIP",
+			status: osconfigv1.InfrastructureStatus{
+				Platform: osconfigv1.BareMetalPlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{
+					Type:      osconfigv1.BareMetalPlatformType,
+					BareMetal: &osconfigv1.BareMetalPlatformStatus{APIServerInternalIP: "192.168.111.5"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "unsupported platform",
+			status: osconfigv1.InfrastructureStatus{
+				Platform:       osconfigv1.AWSPlatformType,
+				PlatformStatus: &osconfigv1.PlatformStatus{Type: osconfigv1.AWSPlatformType},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			infra := &osconfigv1.Infrastructure{Status: tc.status}
+			apiVip, ingressVip, err := platformVips(infra)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error, got api %q ingress %q", apiVip, ingressVip)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if apiVip != tc.expectedAPI || ingressVip != tc.expectedIngress {
+				t.Errorf("expected (%q, %q), got (%q, %q)", tc.expectedAPI, tc.expectedIngress, apiVip, ingressVip)
+			}
+		})
+	}
+}
+
+func TestDualStackVips(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		primary     string
+		additional  []string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "single stack",
+			primary:  "192.168.111.5",
+			expected: []string{"192.168.111.5"},
+		},
+		{
+			name:       "dual stack",
+			primary:    "192.168.111.5",
+			additional: []string{"fd2e:6f44:5dd8::5"},
+			expected:   []string{"192.168.111.5", "fd2e:6f44:5dd8::5"},
+		},
+		{
+			name:       "IPv6 primary",
+			primary:    "fd2e:6f44:5dd8::5",
+			additional: []string{"192.168.111.5"},
+			expected:   []string{"fd2e:6f44:5dd8::5", "192.168.111.5"},
+		},
+		{
+			name:        "two VIPs of the same family",
+			primary:     "192.168.111.5",
+			additional:  []string{"192.168.111.6"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid address",
+			primary:     "192.168.111.5",
+			additional:  []string{"api-vip"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vips, err := dualStackVips(tc.primary, tc.additional)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", vips)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(vips, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, vips)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ module github.com/yboaron/cluster-hosted-net-services-operator
 go 1.13
 
 require (
+	github.com/Masterminds/sprig v2.22.0+incompatible
 	github.com/go-bindata/go-bindata v3.1.2+incompatible
 	github.com/go-logr/logr v0.3.0
 	github.com/go-logr/zapr v0.2.0 // indirect
@@ -14,12 +15,14 @@ require (
 	github.com/openshift/cluster-network-operator v0.0.0-00010101000000-000000000000
 	github.com/openshift/library-go v0.0.0-20201215165635-4ee79b1caed5
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.7.1
 	k8s.io/api v0.20.0
 	k8s.io/apimachinery v0.20.1
 	k8s.io/client-go v0.20.0
 	sigs.k8s.io/controller-runtime v0.6.3
 	sigs.k8s.io/controller-tools v0.4.1
 	sigs.k8s.io/kustomize/kustomize/v3 v3.9.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace (
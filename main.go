@@ -21,16 +21,21 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
+	osconfigv1 "github.com/openshift/api/config/v1"
 	osclientset "github.com/openshift/client-go/config/clientset/versioned"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
 	"github.com/yboaron/cluster-hosted-net-services-operator/controllers"
@@ -44,26 +49,45 @@ var (
 	scheme           = runtime.NewScheme()
 	setupLog         = ctrl.Log.WithName("setup")
 	opertorNamespace string
+	handlerNamespace string
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(osconfigv1.AddToScheme(scheme))
 
 	utilruntime.Must(clusterhostednetservicesopenshiftiov1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := runRender(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "render failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
-	var imagesJSONFilename string
+	var imagesConfigMap string
+	var deployTimeout time.Duration
+	var manifestDir string
+	var dryRun bool
 
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.StringVar(&imagesJSONFilename, "images-json", "/etc/cluster-hosted-net-services-operator/images/images.json",
-		"The location of the file containing the images to use for our operands.")
+	flag.StringVar(&imagesConfigMap, "images-configmap", "cluster-hosted-net-services-operator-images",
+		"The ConfigMap of the operator namespace containing the images to use for our operands.")
+	flag.DurationVar(&deployTimeout, "deploy-timeout", controllers.DefaultDeployTimeout,
+		"How long the handler DaemonSets may take to roll out before the operator reports DeployTimedOut.")
+	flag.StringVar(&manifestDir, "handler-manifests-dir", "",
+		"A directory whose cluster-hosted subdirectory overrides the handler manifests embedded in the binary, for development.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Only record in the Config status the changes the reconciles would make to the handler objects, using server-side dry-run.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -78,6 +102,18 @@ func main() {
 		ctrl.Log.Info("Environment variable COMPONENT_NAMESPACE not provided")
 	}
 
+	handlerNamespace = os.Getenv("HANDLER_NAMESPACE")
+	if handlerNamespace == "" {
+		ctrl.Log.Info("Environment variable HANDLER_NAMESPACE not provided")
+	}
+
+	// The handler resources live in their own namespace, which has to be
+	// cached as well to watch them
+	cacheNamespaces := []string{opertorNamespace}
+	if handlerNamespace != "" && handlerNamespace != opertorNamespace {
+		cacheNamespaces = append(cacheNamespaces, handlerNamespace)
+	}
+
 	config := ctrl.GetConfigOrDie()
 	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme:             scheme,
@@ -86,6 +122,7 @@ func main() {
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "3513afbb.my.domain",
 		Namespace:          opertorNamespace,
+		NewCache:           cache.MultiNamespacedCacheBuilder(cacheNamespaces),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -101,7 +138,7 @@ func main() {
 	}
 	if !enabled {
 		//Set ClusterOperator status to disabled=true, available=true
-		err = controllers.SetCOInDisabledState(osClient, releaseVersion)
+		err = controllers.SetCOInDisabledState(osClient, releaseVersion, opertorNamespace)
 		if err != nil {
 			setupLog.Error(err, "unable to set baremetal ClusterOperator to Disabled")
 			os.Exit(1)
@@ -109,20 +146,76 @@ func main() {
 	}
 
 	if err = (&controllers.ConfigReconciler{
-		Client:         mgr.GetClient(),
-		Log:            ctrl.Log.WithName("controllers").WithName("Config"),
-		Scheme:         mgr.GetScheme(),
-		ImagesFilename: imagesJSONFilename,
-		OSClient:       osClient,
-		ReleaseVersion: releaseVersion,
+		Client:           mgr.GetClient(),
+		Log:              ctrl.Log.WithName("controllers").WithName("Config"),
+		Scheme:           mgr.GetScheme(),
+		Namespace:        opertorNamespace,
+		HandlerNamespace: handlerNamespace,
+		ImagesConfigMap:  imagesConfigMap,
+		OSClient:         osClient,
+		ReleaseVersion:   releaseVersion,
+		DeployTimeout:    deployTimeout,
+		ManifestDir:      manifestDir,
+		DryRun:           dryRun,
+		Recorder:         mgr.GetEventRecorderFor(names.ControllerComponentName),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Config")
 		os.Exit(1)
 	}
 
-	// Create default SriovOperatorConfig
-	err = createDefaultOperatorConfig(ctrl.GetConfigOrDie())
-	if err != nil {
+	if err = (&controllers.VipOwnerReconciler{
+		Client:           mgr.GetClient(),
+		Log:              ctrl.Log.WithName("controllers").WithName("VipOwner"),
+		Namespace:        opertorNamespace,
+		HandlerNamespace: handlerNamespace,
+		Recorder:         mgr.GetEventRecorderFor(names.ControllerComponentName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VipOwner")
+		os.Exit(1)
+	}
+
+	// The VIPs only exist on the platforms the operator is enabled on
+	if enabled {
+		if err = mgr.Add(&controllers.VipProber{
+			Client:           mgr.GetClient(),
+			Log:              ctrl.Log.WithName("controllers").WithName("VipProber"),
+			OSClient:         osClient,
+			Namespace:        opertorNamespace,
+			HandlerNamespace: handlerNamespace,
+		}); err != nil {
+			setupLog.Error(err, "unable to create VIP prober")
+			os.Exit(1)
+		}
+	}
+
+	// The webhook server needs serving certificates, set ENABLE_WEBHOOKS=false
+	// to run the manager locally without them
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&controllers.ConfigValidator{
+			Client:    mgr.GetAPIReader(),
+			Namespace: opertorNamespace,
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Config")
+			os.Exit(1)
+		}
+	}
+
+	// Create default Config once the manager runs, the creation goes through
+	// the validating webhook served by the manager itself
+	if err = mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		err := wait.PollImmediateUntil(5*time.Second, func() (bool, error) {
+			if err := createDefaultOperatorConfig(config); err != nil {
+				setupLog.Error(err, "unable to create default ClusterHostedNetServicesConfig, retrying")
+				return false, nil
+			}
+			return true, nil
+		}, stop)
+		if err == wait.ErrWaitTimeout {
+			// The manager is stopping
+			return nil
+		}
+		return err
+	})); err != nil {
 		setupLog.Error(err, "unable to create default ClusterHostedNetServicesConfig")
 		os.Exit(1)
 	}
@@ -17,8 +17,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
 )
 
+// ImagesJSONKey is the ConfigMap key holding the images, in the same format as
+// the images file.
+const ImagesJSONKey = "images.json"
+
 type Images struct {
 	BaremetalRuntimecfg  string `json:"baremetalRuntimecfg"`
 	HaproxyRouter        string `json:"haproxyRouter"`
@@ -27,14 +34,54 @@ type Images struct {
 	Coredns              string `json:"coredns"`
 }
 
+// imageReference matches name[:tag][@digest] image references, following the
+// grammar of github.com/docker/distribution/reference.
+var imageReference = func() *regexp.Regexp {
+	domainComponent := `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	domain := domainComponent + `(?:\.` + domainComponent + `)*(?::[0-9]+)?`
+	pathComponent := `[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*`
+	name := `(?:` + domain + `/)?` + pathComponent + `(?:/` + pathComponent + `)*`
+	tag := `[\w][\w.-]{0,127}`
+	digest := `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+	return regexp.MustCompile(`^` + name + `(?::` + tag + `)?(?:@` + digest + `)?$`)
+}()
+
 func GetContainerImages(containerImages *Images, imagesFilePath string) error {
 	//read images.json file
 	jsonData, err := ioutil.ReadFile(filepath.Clean(imagesFilePath))
 	if err != nil {
 		return fmt.Errorf("unable to read file %s", imagesFilePath)
 	}
+	if err := ParseContainerImages(containerImages, jsonData); err != nil {
+		return fmt.Errorf("invalid images file %s: %v", imagesFilePath, err)
+	}
+	return nil
+}
+
+// ParseContainerImages reads the images from their JSON representation and
+// validates them.
+func ParseContainerImages(containerImages *Images, jsonData []byte) error {
 	if err := json.Unmarshal(jsonData, containerImages); err != nil {
-		return fmt.Errorf("unable to unmarshal image names from file %s ", imagesFilePath)
+		return fmt.Errorf("unable to unmarshal image names: %v", err)
+	}
+	return containerImages.Validate()
+}
+
+// Validate checks that every image is set to a well-formed image reference.
+func (i *Images) Validate() error {
+	invalid := []string{}
+	value := reflect.ValueOf(*i)
+	for n := 0; n < value.NumField(); n++ {
+		name := strings.Split(value.Type().Field(n).Tag.Get("json"), ",")[0]
+		image := value.Field(n).String()
+		if image == "" {
+			invalid = append(invalid, fmt.Sprintf("%s is not set", name))
+		} else if !imageReference.MatchString(image) {
+			invalid = append(invalid, fmt.Sprintf("%s: %q is not a valid image reference", name, image))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%s", strings.Join(invalid, ", "))
 	}
 	return nil
 }
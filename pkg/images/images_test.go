@@ -0,0 +1,62 @@
+package images
+
+import (
+	"testing"
+)
+
+func TestParseContainerImages(t *testing.T) {
+	testCases := []struct {
+		name          string
+		json          string
+		expectedError bool
+	}{
+		{
+			name: "valid",
+			json: `{
+				"baremetalRuntimecfg": "registry.svc.ci.openshift.org/openshift:baremetal-runtimecfg",
+				"haproxyRouter": "quay.io/openshift/origin-haproxy-router:4.7",
+				"keepalivedIpfailover": "localhost:5000/keepalived-ipfailover",
+				"mdnsPublisher": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				"coredns": "coredns"
+			}`,
+		},
+		{
+			name: "missing image",
+			json: `{
+				"baremetalRuntimecfg": "registry.svc.ci.openshift.org/openshift:baremetal-runtimecfg",
+				"haproxyRouter": "registry.svc.ci.openshift.org/openshift:haproxy-router",
+				"keepalivedIpfailover": "registry.svc.ci.openshift.org/openshift:keepalived-ipfailover",
+				"mdnsPublisher": "registry.svc.ci.openshift.org/openshift:mdns-publisher"
+			}`,
+			expectedError: true,
+		},
+		{
+			name: "malformed image",
+			json: `{
+				"baremetalRuntimecfg": "registry.svc.ci.openshift.org/openshift:baremetal-runtimecfg",
+				"haproxyRouter": "registry.svc.ci.openshift.org/openshift:haproxy-router",
+				"keepalivedIpfailover": "registry.svc.ci.openshift.org/OpenShift:keepalived-ipfailover",
+				"mdnsPublisher": "registry.svc.ci.openshift.org/openshift:mdns-publisher",
+				"coredns": "registry.svc.ci.openshift.org/openshift:coredns"
+			}`,
+			expectedError: true,
+		},
+		{
+			name:          "not JSON",
+			json:          `baremetalRuntimecfg: registry.svc.ci.openshift.org/openshift:baremetal-runtimecfg`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ParseContainerImages(&Images{}, []byte(tc.json))
+			if tc.expectedError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
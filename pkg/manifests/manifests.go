@@ -0,0 +1,111 @@
+//go:generate go run -mod=vendor ../../vendor/github.com/go-bindata/go-bindata/go-bindata/ -nometadata -pkg $GOPACKAGE -prefix ../../deploy/handler/ -ignore=bindata.go ../../deploy/handler/...
+//go:generate gofmt -s -l -w bindata.go
+
+package manifests
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+	"github.com/pkg/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// handlerDirs maps every handler manifest directory to the deploy/handler
+// files it's made of. It's the layout expected under the cluster-hosted
+// directory when the manifests are read from disk.
+var handlerDirs = map[string][]string{
+	"namespace":                   {"namespace.yaml"},
+	"rbac":                        {"role.yaml", "role_binding.yaml", "service_account.yaml"},
+	"keepalived-configmap":        {"keepalived/config_template.yaml"},
+	"keepalived-daemonset":        {"keepalived/daemonset.yaml"},
+	"keepalived-worker-daemonset": {"keepalived/worker_daemonset.yaml"},
+	"haproxy-configmap":           {"haproxy/config_template.yaml"},
+	"haproxy-daemonset":           {"haproxy/daemonset.yaml"},
+	"mdns-configmap":              {"mdns/config_template.yaml"},
+	"mdns-daemonset":              {"mdns/daemonset.yaml"},
+	"coredns-configmap":           {"coredns/config_template.yaml"},
+	"coredns-daemonset":           {"coredns/daemonset.yaml"},
+}
+
+// HandlerDirs returns the names of the handler manifest directories.
+func HandlerDirs() []string {
+	dirs := make([]string, 0, len(handlerDirs))
+	for dir := range handlerDirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// RenderHandlerDir renders the handler manifests of a directory. The manifests
+// embedded in the binary are used unless overrideDir is set, in which case they
+// are read from the cluster-hosted/<dir> directory below it.
+func RenderHandlerDir(overrideDir, dir string, data *render.RenderData) ([]*uns.Unstructured, error) {
+	if overrideDir != "" {
+		return render.RenderDir(filepath.Join(overrideDir, "cluster-hosted", dir), data)
+	}
+
+	files, ok := handlerDirs[dir]
+	if !ok {
+		return nil, fmt.Errorf("unknown handler manifest directory %s", dir)
+	}
+	out := []*uns.Unstructured{}
+	for _, file := range files {
+		source, err := Asset(file)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := renderTemplate(file, source, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "error rendering manifests")
+		}
+		out = append(out, objs...)
+	}
+	return out, nil
+}
+
+// renderTemplate renders and parses one or more k8s api objects the same way
+// render.RenderTemplate does for a file on disk.
+func renderTemplate(name string, source []byte, d *render.RenderData) ([]*uns.Unstructured, error) {
+	tmpl := template.New(name).Option("missingkey=error")
+	if d.Funcs != nil {
+		tmpl.Funcs(d.Funcs)
+	}
+	tmpl.Funcs(sprig.TxtFuncMap())
+
+	if _, err := tmpl.Parse(string(source)); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest %s as template", name)
+	}
+
+	rendered := bytes.Buffer{}
+	if err := tmpl.Execute(&rendered, d.Data); err != nil {
+		return nil, errors.Wrapf(err, "failed to render manifest %s", name)
+	}
+
+	out := []*uns.Unstructured{}
+	if len(strings.TrimSpace(rendered.String())) == 0 {
+		return out, nil
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(&rendered, 4096)
+	for {
+		u := uns.Unstructured{}
+		if err := decoder.Decode(&u); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrapf(err, "failed to unmarshal manifest %s", name)
+		}
+		out = append(out, &u)
+	}
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package manifests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/cluster-network-operator/pkg/render"
+)
+
+func TestEmbeddedHandlerManifests(t *testing.T) {
+	t.Parallel()
+
+	mapped := map[string]bool{}
+	for _, dir := range HandlerDirs() {
+		for _, file := range handlerDirs[dir] {
+			mapped[file] = true
+		}
+	}
+
+	for _, name := range AssetNames() {
+		if !mapped[name] {
+			t.Errorf("%s isn't part of any handler manifest directory", name)
+		}
+		delete(mapped, name)
+
+		onDisk, err := ioutil.ReadFile(filepath.Join("../../deploy/handler", name))
+		if err != nil {
+			t.Errorf("%s is embedded but can't be read from deploy/handler: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(MustAsset(name), onDisk) {
+			t.Errorf("embedded %s is outdated, run make generate", name)
+		}
+	}
+	for name := range mapped {
+		t.Errorf("%s isn't embedded, run make generate", name)
+	}
+}
+
+func TestRenderHandlerDir(t *testing.T) {
+	t.Parallel()
+
+	data := render.MakeRenderData()
+	data.Data["HandlerNamespace"] = "openshift-cluster-hosted"
+
+	objs, err := RenderHandlerDir("", "namespace", &data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objs) != 1 || objs[0].GetKind() != "Namespace" || objs[0].GetName() != "openshift-cluster-hosted" {
+		t.Errorf("unexpected objects rendered: %v", objs)
+	}
+
+	if _, err := RenderHandlerDir("", "unknown", &data); err == nil {
+		t.Errorf("expected an error for an unknown directory")
+	}
+}
@@ -0,0 +1,174 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	osconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+	"github.com/yboaron/cluster-hosted-net-services-operator/controllers"
+	"github.com/yboaron/cluster-hosted-net-services-operator/pkg/images"
+)
+
+// runRender implements the render subcommand, which writes the handler
+// manifests a Config would produce without reaching a cluster.
+func runRender(args []string) error {
+	var configFile, infraFile, imagesFile, outDir, handlerNamespace, manifestDir string
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	fs.StringVar(&configFile, "config", "", "The Config CR to render the handler manifests of.")
+	fs.StringVar(&infraFile, "infra", "", "The Infrastructure cluster object holding the platform VIPs.")
+	fs.StringVar(&imagesFile, "images", "", "The images file, in the format of the images.json key of the images ConfigMap.")
+	fs.StringVar(&outDir, "out", "", "The directory the manifests are written to, one file per object. They are written to stdout when empty.")
+	fs.StringVar(&handlerNamespace, "handler-namespace", "cluster-hosted-net-services", "The namespace of the handler resources.")
+	fs.StringVar(&manifestDir, "handler-manifests-dir", "",
+		"A directory whose cluster-hosted subdirectory overrides the handler manifests embedded in the binary.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if configFile == "" || infraFile == "" || imagesFile == "" {
+		return fmt.Errorf("--config, --infra and --images are required")
+	}
+
+	instance := &clusterhostednetservicesopenshiftiov1beta1.Config{}
+	if err := decodeFile(configFile, instance); err != nil {
+		return err
+	}
+	defaultConfigSpec(&instance.Spec)
+	// Reject what the webhook would, the rendered manifests couldn't be
+	// applied to a cluster
+	if err := controllers.ValidateConfigSpec(&instance.Spec); err != nil {
+		return errors.Wrapf(err, "invalid Config %s", configFile)
+	}
+	if instance.Name == "" {
+		instance.Name = controllers.ClusterHostedNetServicesConfigCR
+	}
+
+	infra := &osconfigv1.Infrastructure{}
+	if err := decodeFile(infraFile, infra); err != nil {
+		return err
+	}
+
+	containerImages := &images.Images{}
+	if err := images.GetContainerImages(containerImages, imagesFile); err != nil {
+		return err
+	}
+
+	r := &controllers.ConfigReconciler{
+		HandlerNamespace: handlerNamespace,
+		ManifestDir:      manifestDir,
+	}
+	objs, err := r.RenderManifests(instance, infra, containerImages)
+	if err != nil {
+		return err
+	}
+
+	if outDir == "" {
+		return writeManifests(os.Stdout, objs)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	for i, obj := range objs {
+		name := fmt.Sprintf("%03d_%s_%s.yaml", i, strings.ToLower(obj.GetKind()), obj.GetName())
+		f, err := os.Create(filepath.Join(outDir, name))
+		if err != nil {
+			return err
+		}
+		err = writeManifests(f, []*uns.Unstructured{obj})
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeFile(path string, into interface{}) error {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrapf(err, "unable to read %s", path)
+	}
+	if err := yaml.Unmarshal(data, into); err != nil {
+		return errors.Wrapf(err, "unable to decode %s", path)
+	}
+	return nil
+}
+
+// defaultConfigSpec applies the CRD schema defaults, which the API server
+// isn't there to fill offline. TestDefaultConfigSpec checks it against the
+// CRD.
+func defaultConfigSpec(spec *clusterhostednetservicesopenshiftiov1beta1.ConfigSpec) {
+	for _, field := range []*clusterhostednetservicesopenshiftiov1beta1.EnableDisable{
+		&spec.LoadBalancer.ApiLoadbalance,
+		&spec.LoadBalancer.ApiVrrpCheck,
+		&spec.LoadBalancer.DefaultIngressHA,
+		&spec.DNS.NodesResolution,
+		&spec.DNS.ApiResolution,
+		&spec.DNS.AppsResolution,
+	} {
+		if *field == "" {
+			*field = "Enable"
+		}
+	}
+	if spec.LoadBalancer.Unicast == "" {
+		spec.LoadBalancer.Unicast = "Disable"
+	}
+
+	if spec.Probes.APIPort == 0 {
+		spec.Probes.APIPort = controllers.DefaultAPIProbePort
+	}
+	if spec.Probes.IngressPort == 0 {
+		spec.Probes.IngressPort = controllers.DefaultIngressProbePort
+	}
+	if spec.Probes.Timeout == nil {
+		spec.Probes.Timeout = &metav1.Duration{Duration: controllers.DefaultProbeTimeout}
+	}
+	if spec.Probes.Period == nil {
+		spec.Probes.Period = &metav1.Duration{Duration: controllers.DefaultProbePeriod}
+	}
+	if spec.Alerts.VipFlappingThreshold == 0 {
+		spec.Alerts.VipFlappingThreshold = controllers.DefaultVipFlappingThreshold
+	}
+}
+
+func writeManifests(w io.Writer, objs []*uns.Unstructured) error {
+	for _, obj := range objs {
+		data, err := sigsyaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
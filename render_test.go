@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	clusterhostednetservicesopenshiftiov1beta1 "github.com/yboaron/cluster-hosted-net-services-operator/api/v1beta1"
+)
+
+// schemaDefaults collects the defaults of the scalar fields of a CRD schema,
+// keyed by their dotted path. The CRD is read as unstructured data, the
+// apiextensions types aren't a dependency of the operator.
+func schemaDefaults(path string, schema map[string]interface{}, defaults map[string]interface{}) {
+	if value, ok := schema["default"]; ok && schema["type"] != "object" {
+		defaults[path] = value
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, property := range properties {
+		if property, ok := property.(map[string]interface{}); ok {
+			schemaDefaults(strings.TrimPrefix(path+"."+name, "."), property, defaults)
+		}
+	}
+}
+
+func TestDefaultConfigSpec(t *testing.T) {
+	t.Parallel()
+
+	data, err := ioutil.ReadFile("config/crd/bases/cluster-hosted-net-services.openshift.io_configs.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	crd := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &crd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	versions, _, err := uns.NestedSlice(crd, "spec", "versions")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defaults := map[string]interface{}{}
+	for _, version := range versions {
+		version, ok := version.(map[string]interface{})
+		if !ok {
+			t.Fatalf("unexpected CRD version %v", version)
+		}
+		spec, _, err := uns.NestedMap(version, "schema", "openAPIV3Schema", "properties", "spec")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		schemaDefaults("", spec, defaults)
+	}
+	if len(defaults) == 0 {
+		t.Fatalf("no default found in the CRD")
+	}
+
+	spec := &clusterhostednetservicesopenshiftiov1beta1.ConfigSpec{}
+	defaultConfigSpec(spec)
+	data, err = json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defaulted := map[string]interface{}{}
+	if err := json.Unmarshal(data, &defaulted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for path, expected := range defaults {
+		var value interface{} = defaulted
+		for _, name := range strings.Split(path, ".") {
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = fields[name]
+		}
+		// The numbers are compared once encoded, the YAML and JSON decoders
+		// don't pick the same types for them
+		expectedJSON, _ := json.Marshal(expected)
+		valueJSON, _ := json.Marshal(value)
+		if string(valueJSON) != string(expectedJSON) {
+			t.Errorf("%s: expected the CRD default %s, got %s", path, expectedJSON, valueJSON)
+		}
+	}
+}
+
+func TestRunRenderRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "render")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The API VRRP check is left enabled by default
+	configFile := filepath.Join(dir, "config.yaml")
+	config := `apiVersion: cluster-hosted-net-services.openshift.io/v1beta1
+kind: Config
+metadata:
+  name: cluster
+spec:
+  loadbalancer:
+    apiloadbalance: Disable
+`
+	if err := ioutil.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = runRender([]string{"--config", configFile, "--infra", filepath.Join(dir, "infra.yaml"), "--images", filepath.Join(dir, "images.json")})
+	if err == nil || !strings.Contains(err.Error(), "apivrrpcheck") {
+		t.Errorf("expected the Config to be rejected, got %v", err)
+	}
+}